@@ -0,0 +1,58 @@
+// Command freq enumerates every hand of a size dealt from the deck of a
+// game type and prints how many hands and classes each ranking has:
+//
+//	go run ./cmd/freq -game shortdeck -cards 7
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+var gameTypes = map[string]hand.GameType{
+	"standard":  hand.GameTypeStandard,
+	"shortdeck": hand.GameTypeShortDeck,
+}
+
+func main() {
+	game := flag.String("game", "standard", "game type: standard or shortdeck")
+	cards := flag.Int("cards", 5, "number of cards in each hand")
+	flag.Parse()
+
+	g, ok := gameTypes[*game]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "freq: unknown game type %q\n", *game)
+		os.Exit(2)
+	}
+	f, err := hand.CountFrequencies(*cards, hand.WithGameType(g))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "freq:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s, %d card hands\n\n", hand.Scheme(g).Name(), f.Size)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "ranking\tbest hand\thands\tprobability\tclasses\t")
+	kinds := map[hand.Ranking]hand.DescriptionKind{}
+	for i := len(f.Rankings) - 1; i >= 0; i-- {
+		r := f.Rankings[i]
+		if r.Hands == 0 {
+			continue
+		}
+		kinds[r.Ranking] = r.Kind
+		fmt.Fprintf(w, "%v\t%s\t%d\t%.6f%%\t%d\t\n", r.Kind, r.Best, r.Hands, 100*r.Probability(f), r.Classes)
+	}
+	fmt.Fprintf(w, "total\t\t%d\t%.6f%%\t%d\t\n", f.Hands, 100.0, f.Classes)
+	w.Flush()
+
+	if inversions := f.Inversions(); len(inversions) > 0 {
+		fmt.Println("\nrankings that beat a ranking with as many hands or fewer:")
+		for _, r := range inversions {
+			fmt.Printf("  %v\n", kinds[r])
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package equity
+
+import (
+	"context"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+// checkEvery is the number of runouts between checks for cancellation.
+const checkEvery = 1 << 10
+
+// Enumerate calculates the exact equity of each player of the spot by
+// dealing every remaining runout of the board.  Hands are compared with
+// the ranking scheme of the spot's game type.  Enumerate returns the
+// context's error if it's cancelled before every runout is dealt.  Every
+// player must have hole cards, use Simulate for unknown hole cards.
+func Enumerate(ctx context.Context, s Spot) (*Result, error) {
+	if err := s.validate(false); err != nil {
+		return nil, err
+	}
+	live := s.live()
+	e := newEvaluator(s)
+	t := newTally(len(s.Players))
+	board := append(make([]hand.Card, 0, 5), s.Board...)
+	var err error
+	util.ForEachCombination(len(live), 5-len(s.Board), func(idx []int) bool {
+		if t.runouts%checkEvery == 0 {
+			if err = ctx.Err(); err != nil {
+				return false
+			}
+		}
+		board = board[:len(s.Board)]
+		for _, i := range idx {
+			board = append(board, live[i])
+		}
+		e.values(board, t.values)
+		t.showdown(t.values)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.result(), nil
+}
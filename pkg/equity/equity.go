@@ -0,0 +1,274 @@
+// Package equity calculates the share of the pot each player of a
+// hold'em hand can expect to win.
+package equity
+
+import (
+	"errors"
+	"math"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+var (
+	// ErrInvalidPlayerCount is returned if a spot has fewer than two or
+	// more than ten players.
+	ErrInvalidPlayerCount = errors.New("equity: there must be between 2 and 10 players")
+
+	// ErrInvalidHoleCards is returned if a player has neither two hole
+	// cards nor a range.
+	ErrInvalidHoleCards = errors.New("equity: players must have two hole cards")
+
+	// ErrInvalidBoard is returned if a spot's board has more than five
+	// cards.
+	ErrInvalidBoard = errors.New("equity: the board can't have more than five cards")
+
+	// ErrDuplicateCard is returned if a card is held by more than one
+	// player or appears in the board or dead cards as well.
+	ErrDuplicateCard = errors.New("equity: card is used more than once")
+
+	// ErrInvalidCard is returned if a card isn't in the deck of the
+	// spot's game type.
+	ErrInvalidCard = errors.New("equity: card isn't in the deck")
+
+	// ErrInvalidRanges is returned if a spot's ranges don't match its
+	// players or a player has both hole cards and a range.
+	ErrInvalidRanges = errors.New("equity: ranges must be given for players without hole cards")
+
+	// ErrEmptyRange is returned by Simulate if every hole card combination
+	// of a range uses a known card.
+	ErrEmptyRange = errors.New("equity: range has no hole cards left after card removal")
+
+	// ErrRangeConflict is returned by Simulate if the ranges repeatedly
+	// fail to be dealt without two players sharing a card.
+	ErrRangeConflict = errors.New("equity: ranges can't be dealt without sharing cards")
+
+	// ErrNotEnoughCards is returned if the live cards can't deal the
+	// unknown hole cards and the rest of the board.
+	ErrNotEnoughCards = errors.New("equity: not enough live cards to deal the hole cards and board")
+)
+
+// A Spot is a hold'em situation: the hole cards of each player, the
+// board dealt so far, and dead cards that can't be dealt such as mucked
+// or burned cards.  Hands are ranked and the deck is formed with the
+// ranking scheme of GameType.  If Ranges is given it must have a range
+// or nil for every player, and players with a range must not have hole
+// cards.
+type Spot struct {
+	Players  [][]hand.Card
+	Ranges   []*Range
+	Board    []hand.Card
+	Dead     []hand.Card
+	GameType hand.GameType
+}
+
+// validate returns an error if the spot can't be dealt from the deck.
+// Players without hole cards are allowed if unknown is true.
+func (s Spot) validate(unknown bool) error {
+	if len(s.Players) < 2 || len(s.Players) > 10 {
+		return ErrInvalidPlayerCount
+	}
+	for _, p := range s.Players {
+		if len(p) != 2 && !(unknown && len(p) == 0) {
+			return ErrInvalidHoleCards
+		}
+	}
+	if len(s.Ranges) != 0 && len(s.Ranges) != len(s.Players) {
+		return ErrInvalidRanges
+	}
+	for i, r := range s.Ranges {
+		if r != nil && len(s.Players[i]) != 0 {
+			return ErrInvalidRanges
+		}
+	}
+	if len(s.Board) > 5 {
+		return ErrInvalidBoard
+	}
+	deck := hand.NewCardSet(hand.Scheme(s.GameType).Cards()...)
+	known := s.known()
+	if hand.HasDuplicates(known...) {
+		return ErrDuplicateCard
+	}
+	for _, c := range known {
+		if !deck.Contains(c) {
+			return ErrInvalidCard
+		}
+	}
+	if len(s.live()) < s.dealt() {
+		return ErrNotEnoughCards
+	}
+	return nil
+}
+
+// dealt returns the number of cards dealt from the live cards for each
+// runout: the hole cards of players without them and the rest of the
+// board.
+func (s Spot) dealt() int {
+	n := 5 - len(s.Board)
+	for _, p := range s.Players {
+		if len(p) == 0 {
+			n += 2
+		}
+	}
+	return n
+}
+
+// known returns every card of the spot.
+func (s Spot) known() []hand.Card {
+	cards := []hand.Card{}
+	for _, p := range s.Players {
+		cards = append(cards, p...)
+	}
+	cards = append(cards, s.Board...)
+	return append(cards, s.Dead...)
+}
+
+// live returns the cards of the deck that can still be dealt.
+func (s Spot) live() []hand.Card {
+	known := hand.NewCardSet(s.known()...)
+	cards := []hand.Card{}
+	for _, c := range hand.Scheme(s.GameType).Cards() {
+		if !known.Contains(c) {
+			cards = append(cards, c)
+		}
+	}
+	return cards
+}
+
+// Result is the outcome of an equity calculation over a number of
+// runouts of the board.
+type Result struct {
+	Runouts int
+	Players []Player
+}
+
+// Player is one player's share of the runouts of a Result.  Win, Tie,
+// and Loss are the fractions of runouts the player won outright, split,
+// and lost.  Equity is the fraction of the pot the player can expect,
+// counting a split between n players as 1/n of a win.
+type Player struct {
+	Wins   int
+	Ties   int
+	Losses int
+	Win    float64
+	Tie    float64
+	Loss   float64
+	Equity float64
+}
+
+// tally accumulates the showdowns of the runouts of a spot.  squares
+// holds the sum of the squares of each player's share of the pots for
+// estimating the variance of the equity.
+type tally struct {
+	runouts int
+	players []Player
+	shares  []float64
+	squares []float64
+	values  []int
+}
+
+func newTally(players int) *tally {
+	return &tally{
+		players: make([]Player, players),
+		shares:  make([]float64, players),
+		squares: make([]float64, players),
+		values:  make([]int, players),
+	}
+}
+
+// showdown records the outcome of a runout in which each player's hand
+// has the given value.
+func (t *tally) showdown(values []int) {
+	t.runouts++
+	best, winners := 0, 0
+	for _, v := range values {
+		switch {
+		case v > best:
+			best, winners = v, 1
+		case v == best:
+			winners++
+		}
+	}
+	for i, v := range values {
+		switch {
+		case v < best:
+			t.players[i].Losses++
+		case winners == 1:
+			t.players[i].Wins++
+			t.shares[i]++
+			t.squares[i]++
+		default:
+			share := 1 / float64(winners)
+			t.players[i].Ties++
+			t.shares[i] += share
+			t.squares[i] += share * share
+		}
+	}
+}
+
+// add adds the runouts recorded by o.
+func (t *tally) add(o *tally) {
+	t.runouts += o.runouts
+	for i := range t.players {
+		t.players[i].Wins += o.players[i].Wins
+		t.players[i].Ties += o.players[i].Ties
+		t.players[i].Losses += o.players[i].Losses
+		t.shares[i] += o.shares[i]
+		t.squares[i] += o.squares[i]
+	}
+}
+
+// stdErr returns the standard error of the player's equity.
+func (t *tally) stdErr(player int) float64 {
+	if t.runouts < 2 {
+		return math.Inf(1)
+	}
+	n := float64(t.runouts)
+	mean := t.shares[player] / n
+	variance := (t.squares[player] - n*mean*mean) / (n - 1)
+	return math.Sqrt(math.Max(variance, 0) / n)
+}
+
+// result returns the Result of the recorded runouts.
+func (t *tally) result() *Result {
+	r := &Result{Runouts: t.runouts, Players: make([]Player, len(t.players))}
+	for i, p := range t.players {
+		if t.runouts > 0 {
+			n := float64(t.runouts)
+			p.Win = float64(p.Wins) / n
+			p.Tie = float64(p.Ties) / n
+			p.Loss = float64(p.Losses) / n
+			p.Equity = t.shares[i] / n
+		}
+		r.Players[i] = p
+	}
+	return r
+}
+
+// evaluator forms the hands of the players of a spot for a board.
+type evaluator struct {
+	spot  Spot
+	cards [][]hand.Card
+}
+
+func newEvaluator(s Spot) *evaluator {
+	e := &evaluator{spot: s, cards: make([][]hand.Card, len(s.Players))}
+	for i, p := range s.Players {
+		e.cards[i] = make([]hand.Card, 2, 7)
+		copy(e.cards[i], p)
+	}
+	return e
+}
+
+// deal gives the player the hole cards.
+func (e *evaluator) deal(player int, c0, c1 hand.Card) {
+	e.cards[player][0], e.cards[player][1] = c0, c1
+}
+
+// values sets the value of each player's best hand with the board.  The
+// hole cards of players without them must be dealt with deal first.
+func (e *evaluator) values(board []hand.Card, values []int) {
+	for i := range e.cards {
+		cards := append(e.cards[i][:2], board...)
+		values[i] = hand.New(cards, hand.WithGameType(e.spot.GameType)).Value()
+	}
+}
@@ -0,0 +1,163 @@
+package equity_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+type enumerateTest struct {
+	name    string
+	spot    equity.Spot
+	runouts int
+	wins    []int
+	ties    []int
+	equity  []float64
+}
+
+var enumerateTests = []enumerateTest{
+	{
+		"flush draw against a set",
+		equity.Spot{
+			Players: [][]hand.Card{Cards("Ah", "Qh"), Cards("Ks", "Kc")},
+			Board:   Cards("2h", "7h", "Kd", "9c"),
+		},
+		44,
+		[]int{7, 37},
+		[]int{0, 0},
+		[]float64{7.0 / 44, 37.0 / 44},
+	},
+	{
+		"royal flush on the board",
+		equity.Spot{
+			Players: [][]hand.Card{Cards("2c", "3c"), Cards("4d", "5d"), Cards("Ah", "Kh")},
+			Board:   Cards("As", "Ks", "Qs", "Js", "Ts"),
+		},
+		1,
+		[]int{0, 0, 0},
+		[]int{1, 1, 1},
+		[]float64{1.0 / 3, 1.0 / 3, 1.0 / 3},
+	},
+	{
+		"short deck flush beats full house",
+		equity.Spot{
+			Players:  [][]hand.Card{Cards("As", "Ks"), Cards("Th", "6h")},
+			Board:    Cards("6s", "9s", "Td", "Tc"),
+			GameType: hand.GameTypeShortDeck,
+		},
+		28,
+		[]int{4, 24},
+		[]int{0, 0},
+		[]float64{4.0 / 28, 24.0 / 28},
+	},
+	{
+		"standard full house beats flush",
+		equity.Spot{
+			Players: [][]hand.Card{Cards("As", "Ks"), Cards("Th", "6h")},
+			Board:   Cards("6s", "9s", "Td", "Tc"),
+			Dead:    Cards("2s", "3s"),
+		},
+		42,
+		[]int{0, 42},
+		[]int{0, 0},
+		[]float64{0, 1},
+	},
+}
+
+func TestEnumerate(t *testing.T) {
+	for _, test := range enumerateTests {
+		r, err := equity.Enumerate(context.Background(), test.spot)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if r.Runouts != test.runouts {
+			t.Fatalf("%s: expected %d runouts got %d", test.name, test.runouts, r.Runouts)
+		}
+		for i, p := range r.Players {
+			if p.Wins != test.wins[i] || p.Ties != test.ties[i] {
+				t.Fatalf("%s: expected player %d to win %d and tie %d got %+v", test.name, i, test.wins[i], test.ties[i], p)
+			}
+			if p.Wins+p.Ties+p.Losses != r.Runouts {
+				t.Fatalf("%s: expected player %d outcomes to add up to %d got %+v", test.name, i, r.Runouts, p)
+			}
+			if math.Abs(p.Equity-test.equity[i]) > 1e-9 {
+				t.Fatalf("%s: expected player %d equity %v got %v", test.name, i, test.equity[i], p.Equity)
+			}
+		}
+	}
+}
+
+func TestEnumerateFlop(t *testing.T) {
+	spot := equity.Spot{
+		Players: [][]hand.Card{Cards("Ah", "Kh"), Cards("Qs", "Qd"), Cards("9c", "8c")},
+		Board:   Cards("Qh", "Jh", "2c"),
+	}
+	r, err := equity.Enumerate(context.Background(), spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Runouts != 903 {
+		t.Fatalf("expected 903 runouts got %d", r.Runouts)
+	}
+	total := 0.0
+	for _, p := range r.Players {
+		total += p.Equity
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Fatalf("expected equities to add up to 1 got %v", total)
+	}
+}
+
+func TestEnumerateErrors(t *testing.T) {
+	tests := []struct {
+		spot equity.Spot
+		err  error
+	}{
+		{equity.Spot{Players: [][]hand.Card{Cards("Ah", "Kh")}}, equity.ErrInvalidPlayerCount},
+		{equity.Spot{Players: [][]hand.Card{Cards("Ah", "Kh", "Qh"), Cards("2c", "3c")}}, equity.ErrInvalidHoleCards},
+		{equity.Spot{Players: [][]hand.Card{Cards("Ah", "Kh"), Cards("Ah", "3c")}}, equity.ErrDuplicateCard},
+		{equity.Spot{
+			Players: [][]hand.Card{Cards("Ah", "Kh"), Cards("2c", "3c")},
+			Board:   Cards("4c", "5c", "6c", "7c", "8c", "9c"),
+		}, equity.ErrInvalidBoard},
+		{equity.Spot{
+			Players:  [][]hand.Card{Cards("Ah", "Kh"), Cards("2c", "3c")},
+			GameType: hand.GameTypeShortDeck,
+		}, equity.ErrInvalidCard},
+		{equity.Spot{
+			Players: [][]hand.Card{Cards("Ah", "Kh"), Cards("2c", "3c")},
+			Dead:    deadExcept(4, Cards("Ah", "Kh", "2c", "3c")...),
+		}, equity.ErrNotEnoughCards},
+	}
+	for _, test := range tests {
+		if _, err := equity.Enumerate(context.Background(), test.spot); err != test.err {
+			t.Fatalf("expected %v got %v", test.err, err)
+		}
+	}
+}
+
+// deadExcept returns the cards of the standard deck that aren't known
+// except for the given number of live cards.
+func deadExcept(live int, known ...hand.Card) []hand.Card {
+	used := hand.NewCardSet(known...)
+	dead := []hand.Card{}
+	for _, c := range hand.StandardCards() {
+		if !used.Contains(c) {
+			dead = append(dead, c)
+		}
+	}
+	return dead[live:]
+}
+
+func TestEnumerateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	spot := equity.Spot{Players: [][]hand.Card{Cards("Ah", "Ad"), Cards("Ks", "Kc")}}
+	if _, err := equity.Enumerate(ctx, spot); err != context.Canceled {
+		t.Fatalf("expected %v got %v", context.Canceled, err)
+	}
+}
@@ -0,0 +1,347 @@
+package equity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// A ParseError describes a problem with range notation.  Pos is the byte
+// offset of the problem in the notation.
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("equity: %s at position %d", e.Msg, e.Pos)
+}
+
+// A Combo is a pair of hole cards and the weight they're held with.
+type Combo struct {
+	Cards  [2]hand.Card
+	Weight float64
+}
+
+// A Range is a weighted set of hold'em hole cards written in range
+// notation such as "TT+, AQs+, KJo, A5s-A2s, 76s:0.5".  A range is a
+// comma separated list of:
+//
+//	TT        a pair
+//	AQs, KJo  a suited or offsuit hand, or both without a suffix
+//	TT+, AQs+ the hand and every better pair or kicker
+//	TT-77     every pair from the first to the second
+//	A5s-A2s   every kicker from the first hand to the second
+//	AhKh      exact hole cards
+//
+// and any of them may be followed by a weight between zero and one such
+// as ":0.5".  Hands listed more than once take their last weight.
+type Range struct {
+	terms []rangeTerm
+}
+
+// ParseRange parses range notation.  Errors are of type *ParseError.
+func ParseRange(s string) (*Range, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, &ParseError{Pos: 0, Msg: "empty range"}
+	}
+	r := &Range{}
+	pos := 0
+	for _, tok := range strings.Split(s, ",") {
+		start := pos + len(tok) - len(strings.TrimLeft(tok, " \t"))
+		body := strings.TrimSpace(tok)
+		if body == "" {
+			return nil, &ParseError{Pos: start, Msg: "empty hand"}
+		}
+		t, err := parseTerm(body, start)
+		if err != nil {
+			return nil, err
+		}
+		r.terms = append(r.terms, t)
+		pos += len(tok) + 1
+	}
+	return r, nil
+}
+
+// MustParseRange is like ParseRange but panics if the notation is
+// invalid.
+func MustParseRange(s string) *Range {
+	r, err := ParseRange(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the range in the notation it was parsed from with hands
+// separated by ", ".
+func (r *Range) String() string {
+	strs := []string{}
+	for _, t := range r.terms {
+		strs = append(strs, t.String())
+	}
+	return strings.Join(strs, ", ")
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r *Range) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *Range) UnmarshalText(text []byte) error {
+	parsed, err := ParseRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = *parsed
+	return nil
+}
+
+// Combos returns the weighted hole cards of the range that don't contain
+// any of the dead cards, such as the board or another player's cards.
+func (r *Range) Combos(dead hand.CardSet) []Combo {
+	combos := []Combo{}
+	index := map[hand.CardSet]int{}
+	for _, t := range r.terms {
+		for _, cards := range t.cards() {
+			key := hand.NewCardSet(cards[0], cards[1])
+			if key.Overlaps(dead) {
+				continue
+			}
+			if i, ok := index[key]; ok {
+				combos[i].Weight = t.weight
+				continue
+			}
+			index[key] = len(combos)
+			combos = append(combos, Combo{Cards: cards, Weight: t.weight})
+		}
+	}
+	return combos
+}
+
+type suitedness int
+
+const (
+	anySuits suitedness = iota
+	suited
+	offsuit
+)
+
+// class is a class of hole cards such as "TT", "AKs", or "KJ".
+type class struct {
+	high  hand.Rank
+	low   hand.Rank
+	suits suitedness
+}
+
+func (c class) isPair() bool {
+	return c.high == c.low
+}
+
+func (c class) String() string {
+	s := c.high.String() + c.low.String()
+	switch c.suits {
+	case suited:
+		s += "s"
+	case offsuit:
+		s += "o"
+	}
+	return s
+}
+
+// cards returns every pair of hole cards of the class.
+func (c class) cards() [][2]hand.Card {
+	cards := [][2]hand.Card{}
+	for s1 := hand.Spades; s1 <= hand.Clubs; s1++ {
+		for s2 := hand.Spades; s2 <= hand.Clubs; s2++ {
+			switch {
+			case c.isPair() && s2 <= s1:
+				continue
+			case c.suits == suited && s1 != s2:
+				continue
+			case c.suits == offsuit && s1 == s2:
+				continue
+			}
+			cards = append(cards, [2]hand.Card{hand.NewCard(c.high, s1), hand.NewCard(c.low, s2)})
+		}
+	}
+	return cards
+}
+
+// rangeTerm is one comma separated hand of a range.
+type rangeTerm struct {
+	from   class
+	to     class
+	plus   bool
+	dash   bool
+	exact  []hand.Card
+	weight float64
+}
+
+// classes returns the classes the term spans.
+func (t rangeTerm) classes() []class {
+	from, to := t.from, t.from
+	switch {
+	case t.plus && t.from.isPair():
+		to = class{high: hand.Ace, low: hand.Ace}
+	case t.plus:
+		to.low = t.from.high - 1
+	case t.dash:
+		to = t.to
+	}
+	if from.low > to.low {
+		from, to = to, from
+	}
+	classes := []class{}
+	for r := from.low; r <= to.low; r++ {
+		c := from
+		c.low = r
+		if from.isPair() {
+			c.high = r
+		}
+		classes = append(classes, c)
+	}
+	return classes
+}
+
+func (t rangeTerm) cards() [][2]hand.Card {
+	if t.exact != nil {
+		return [][2]hand.Card{{t.exact[0], t.exact[1]}}
+	}
+	cards := [][2]hand.Card{}
+	for _, c := range t.classes() {
+		cards = append(cards, c.cards()...)
+	}
+	return cards
+}
+
+func (t rangeTerm) String() string {
+	var s string
+	switch {
+	case t.exact != nil:
+		for _, c := range t.exact {
+			s += c.ASCII()
+		}
+	case t.plus:
+		s = t.from.String() + "+"
+	case t.dash:
+		s = t.from.String() + "-" + t.to.String()
+	default:
+		s = t.from.String()
+	}
+	if t.weight != 1 {
+		s += ":" + strconv.FormatFloat(t.weight, 'g', -1, 64)
+	}
+	return s
+}
+
+// parseTerm parses a hand of a range that starts at pos.
+func parseTerm(s string, pos int) (rangeTerm, error) {
+	t := rangeTerm{weight: 1}
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		w, err := strconv.ParseFloat(s[i+1:], 64)
+		if err != nil || w <= 0 || w > 1 {
+			return t, &ParseError{Pos: pos + i + 1, Msg: fmt.Sprintf("invalid weight %q", s[i+1:])}
+		}
+		t.weight = w
+		s = s[:i]
+	}
+	if c0, c1, ok := parseExact(s); ok {
+		if c0 == c1 {
+			return t, &ParseError{Pos: pos + 2, Msg: fmt.Sprintf("duplicate card %q", s[2:])}
+		}
+		t.exact = []hand.Card{c0, c1}
+		return t, nil
+	}
+	from, n, err := parseClass(s, pos)
+	if err != nil {
+		return t, err
+	}
+	t.from = from
+	rest := s[n:]
+	switch {
+	case rest == "":
+	case rest == "+":
+		t.plus = true
+	case rest[0] == '-':
+		to, m, err := parseClass(rest[1:], pos+n+1)
+		if err != nil {
+			return t, err
+		}
+		if m != len(rest)-1 {
+			return t, unexpected(rest[1+m:], pos+n+1+m)
+		}
+		if from.isPair() != to.isPair() || (!from.isPair() && (from.high != to.high || from.suits != to.suits)) {
+			return t, &ParseError{Pos: pos + n + 1, Msg: fmt.Sprintf("%v can't end a range starting at %v", to, from)}
+		}
+		t.to = to
+		t.dash = true
+	default:
+		return t, unexpected(rest, pos+n)
+	}
+	return t, nil
+}
+
+// parseClass parses a class at the start of s and returns the number of
+// bytes parsed.
+func parseClass(s string, pos int) (class, int, error) {
+	c := class{}
+	if len(s) < 2 {
+		return c, 0, &ParseError{Pos: pos, Msg: fmt.Sprintf("incomplete hand %q", s)}
+	}
+	high, err := parseRank(s[:1], pos)
+	if err != nil {
+		return c, 0, err
+	}
+	low, err := parseRank(s[1:2], pos+1)
+	if err != nil {
+		return c, 0, err
+	}
+	if low > high {
+		return c, 0, &ParseError{Pos: pos, Msg: fmt.Sprintf("higher rank must come first in %q", s[:2])}
+	}
+	c.high, c.low = high, low
+	n := 2
+	if len(s) > 2 && (s[2] == 's' || s[2] == 'o') {
+		if c.isPair() {
+			return c, 0, &ParseError{Pos: pos + 2, Msg: fmt.Sprintf("pair %q can't be suited or offsuit", s[:3])}
+		}
+		c.suits = suited
+		if s[2] == 'o' {
+			c.suits = offsuit
+		}
+		n++
+	}
+	return c, n, nil
+}
+
+// parseExact parses exact hole cards such as "AhKh" and returns false
+// if s isn't two cards.
+func parseExact(s string) (hand.Card, hand.Card, bool) {
+	if len(s) != 4 {
+		return 0, 0, false
+	}
+	c0, err0 := hand.ParseCard(s[:2])
+	c1, err1 := hand.ParseCard(s[2:])
+	if err0 != nil || err1 != nil || c0.IsJoker() || c1.IsJoker() {
+		return 0, 0, false
+	}
+	return c0, c1, true
+}
+
+// parseRank parses the rank of the byte at pos.
+func parseRank(s string, pos int) (hand.Rank, error) {
+	r, err := hand.ParseRank(s)
+	if perr, ok := err.(*hand.ParseError); ok {
+		return 0, &ParseError{Pos: pos + perr.Pos, Msg: perr.Msg}
+	}
+	return r, err
+}
+
+func unexpected(s string, pos int) error {
+	return &ParseError{Pos: pos, Msg: fmt.Sprintf("unexpected %q", s)}
+}
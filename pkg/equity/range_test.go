@@ -0,0 +1,115 @@
+package equity_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+var rangeTests = []struct {
+	notation string
+	dead     []hand.Card
+	combos   int
+	weight   float64
+}{
+	{"TT+", nil, 30, 30},
+	{"AQs+", nil, 8, 8},
+	{"KJo", nil, 12, 12},
+	{"AK", nil, 16, 16},
+	{"A5s-A2s", nil, 16, 16},
+	{"TT-77", nil, 24, 24},
+	{"76s:0.5", nil, 4, 2},
+	{"AhKh", nil, 1, 1},
+	{"AA", Cards("As"), 3, 3},
+	{"AKs, AhKh:0.25", nil, 4, 3.25},
+	{"TT+, AQs+, KJo, A5s-A2s, 76s:0.5", Cards("Ah", "7d", "6d"), 60, 58.5},
+}
+
+func TestParseRange(t *testing.T) {
+	for _, test := range rangeTests {
+		r, err := equity.ParseRange(test.notation)
+		if err != nil {
+			t.Fatalf("%s: %v", test.notation, err)
+		}
+		if r.String() != test.notation {
+			t.Fatalf("expected %s got %s", test.notation, r)
+		}
+		combos := r.Combos(hand.NewCardSet(test.dead...))
+		weight := 0.0
+		for _, c := range combos {
+			weight += c.Weight
+		}
+		if len(combos) != test.combos || weight != test.weight {
+			t.Fatalf("%s: expected %d combos weighing %v got %d weighing %v", test.notation, test.combos, test.weight, len(combos), weight)
+		}
+	}
+}
+
+var rangeErrorTests = []struct {
+	notation string
+	pos      int
+	msg      string
+}{
+	{"", 0, "empty range"},
+	{"TT+, AQx", 7, `unexpected "x"`},
+	{"AA,,KK", 3, "empty hand"},
+	{"77s", 2, `pair "77s" can't be suited or offsuit`},
+	{"KA", 0, `higher rank must come first in "KA"`},
+	{"AK, 1J", 4, `invalid rank '1'`},
+	{"A5s-K2s", 4, "K2s can't end a range starting at A5s"},
+	{"76s:2", 4, `invalid weight "2"`},
+	{"AhAh", 2, `duplicate card "Ah"`},
+}
+
+func TestParseRangeErrors(t *testing.T) {
+	for _, test := range rangeErrorTests {
+		_, err := equity.ParseRange(test.notation)
+		perr, ok := err.(*equity.ParseError)
+		if !ok {
+			t.Fatalf("%s: expected a parse error got %v", test.notation, err)
+		}
+		if perr.Pos != test.pos || perr.Msg != test.msg {
+			t.Fatalf("%s: expected %s at %d got %s at %d", test.notation, test.msg, test.pos, perr.Msg, perr.Pos)
+		}
+	}
+}
+
+func TestRangeVsRange(t *testing.T) {
+	ranges := []*equity.Range{equity.MustParseRange("AA"), equity.MustParseRange("KK")}
+	est, err := equity.RangeVsRange(context.Background(), ranges, nil, equity.Trials(20000), equity.Seed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// aces are about 82% against kings
+	if math.Abs(est.Players[0].Equity-0.82) > 0.02 {
+		t.Fatalf("expected equity near 0.82 got %v", est.Players[0].Equity)
+	}
+}
+
+func TestHandVsRange(t *testing.T) {
+	ranges := []*equity.Range{equity.MustParseRange("KK, QQ")}
+	board := Cards("2c", "3d", "7h", "9s", "Jc")
+	est, err := equity.HandVsRange(context.Background(), Cards("As", "Ah"), ranges, board, equity.Trials(1000), equity.Seed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Players[0].Equity != 1 {
+		t.Fatalf("expected equity 1 got %v", est.Players[0].Equity)
+	}
+
+	ranges = []*equity.Range{equity.MustParseRange("AA")}
+	_, err = equity.HandVsRange(context.Background(), Cards("As", "Ah"), ranges, Cards("Ad"), equity.Seed(1))
+	if err != equity.ErrEmptyRange {
+		t.Fatalf("expected %v got %v", equity.ErrEmptyRange, err)
+	}
+
+	ranges = []*equity.Range{equity.MustParseRange("AA"), equity.MustParseRange("AA")}
+	_, err = equity.RangeVsRange(context.Background(), ranges, Cards("As", "Ah"), equity.Seed(1))
+	if err != equity.ErrRangeConflict {
+		t.Fatalf("expected %v got %v", equity.ErrRangeConflict, err)
+	}
+}
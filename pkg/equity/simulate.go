@@ -0,0 +1,338 @@
+package equity
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+// batchSize is the number of trials dealt with each seed.  Trials are
+// split into batches so that the trials dealt don't depend on the number
+// of workers.
+const batchSize = 1000
+
+// Config represents the configuration options of a simulation.
+type Config struct {
+	trials       int
+	workers      int
+	seed         int64
+	seeded       bool
+	targetStdErr float64
+	confidence   float64
+}
+
+// Trials configures Simulate to deal at most n trials.  The default is
+// 100,000 trials.
+func Trials(n int) func(*Config) {
+	return func(c *Config) {
+		c.trials = n
+	}
+}
+
+// Workers configures Simulate to deal trials on n goroutines.  The
+// default is one goroutine per CPU.
+func Workers(n int) func(*Config) {
+	return func(c *Config) {
+		c.workers = n
+	}
+}
+
+// Seed configures Simulate to seed its random source with the seed, so
+// that the same spot gives the same estimate regardless of the number of
+// workers.  Without a seed the estimate is seeded with the current time.
+func Seed(seed int64) func(*Config) {
+	return func(c *Config) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+// TargetStdErr configures Simulate to stop once the standard error of
+// every player's equity is at most stdErr.
+func TargetStdErr(stdErr float64) func(*Config) {
+	return func(c *Config) {
+		c.targetStdErr = stdErr
+	}
+}
+
+// Confidence configures the confidence level of the intervals of an
+// Estimate.  The default is 0.95.
+func Confidence(level float64) func(*Config) {
+	return func(c *Config) {
+		c.confidence = level
+	}
+}
+
+// An Estimate is a Result estimated from randomly dealt trials.  StdErrs
+// and Intervals hold the standard error and the confidence interval of
+// each player's equity.  Converged is true if the simulation stopped
+// because the target standard error was reached.
+type Estimate struct {
+	Result
+	StdErrs    []float64
+	Intervals  []Interval
+	Confidence float64
+	Converged  bool
+}
+
+// An Interval is the range within which a player's equity is expected at
+// the confidence level of its Estimate.
+type Interval struct {
+	Low  float64
+	High float64
+}
+
+// Simulate estimates the equity of each player of the spot by dealing
+// random runouts of the board.  Players without hole cards are dealt hole
+// cards from their ranges, or random hole cards if they don't have one,
+// each trial.  Trials are dealt in batches on several
+// goroutines, each batch with its own random source seeded from the
+// simulation's source in the way decks are shuffled by a hand.Dealer.
+// ErrNotEnoughCards is returned if the live cards can't deal the unknown
+// hole cards and the rest of the board.  Simulate returns the context's
+// error if it's cancelled.
+func Simulate(ctx context.Context, s Spot, options ...func(*Config)) (*Estimate, error) {
+	c := &Config{
+		trials:     100000,
+		workers:    runtime.GOMAXPROCS(0),
+		confidence: 0.95,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if !c.seeded {
+		c.seed = time.Now().UnixNano()
+	}
+	if c.workers < 1 {
+		c.workers = 1
+	}
+	if err := s.validate(true); err != nil {
+		return nil, err
+	}
+	p, err := newDealPlan(s)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(c.seed))
+	total := newTally(len(s.Players))
+	converged := false
+	batches := (c.trials + batchSize - 1) / batchSize
+	for b := 0; b < batches && !converged; b += c.workers {
+		// each round deals up to one batch per worker and merges them in
+		// order, so the result doesn't depend on the number of workers
+		n := min(c.workers, batches-b)
+		tallies := make([]*tally, n)
+		errs := make([]error, n)
+		var wg sync.WaitGroup
+		for i := 0; i < n; i++ {
+			trials := min(batchSize, c.trials-(b+i)*batchSize)
+			seed := r.Int63()
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				tallies[i], errs[i] = simulateBatch(ctx, p, rand.New(rand.NewSource(seed)), trials)
+			}(i)
+		}
+		wg.Wait()
+		for i := range tallies {
+			if errs[i] != nil {
+				return nil, errs[i]
+			}
+			total.add(tallies[i])
+			if c.targetStdErr > 0 && maxStdErr(total) <= c.targetStdErr {
+				converged = true
+				break
+			}
+		}
+	}
+	return newEstimate(total, c.confidence, converged), nil
+}
+
+// maxAttempts is the number of times the hole cards of players with
+// ranges are sampled for a trial before giving up on finding hole cards
+// that don't share cards.
+const maxAttempts = 1000
+
+// dealPlan describes how the unknown cards of a spot are dealt.  Players
+// in unknown are dealt random hole cards and players with ranges are
+// dealt hole cards sampled from their ranges by weight.
+type dealPlan struct {
+	spot    Spot
+	live    []hand.Card
+	unknown []int
+	ranges  []rangeDealer
+}
+
+func newDealPlan(s Spot) (*dealPlan, error) {
+	p := &dealPlan{spot: s, live: s.live()}
+	known := hand.NewCardSet(s.known()...)
+	for i, cards := range s.Players {
+		if len(cards) != 0 {
+			continue
+		}
+		if len(s.Ranges) == 0 || s.Ranges[i] == nil {
+			p.unknown = append(p.unknown, i)
+			continue
+		}
+		d := rangeDealer{player: i}
+		for _, c := range s.Ranges[i].Combos(known) {
+			d.combos = append(d.combos, c)
+			d.total += c.Weight
+			d.cumulative = append(d.cumulative, d.total)
+		}
+		if len(d.combos) == 0 {
+			return nil, ErrEmptyRange
+		}
+		p.ranges = append(p.ranges, d)
+	}
+	return p, nil
+}
+
+// rangeDealer samples hole cards from a range by weight.
+type rangeDealer struct {
+	player     int
+	combos     []Combo
+	cumulative []float64
+	total      float64
+}
+
+func (d rangeDealer) sample(r *rand.Rand) [2]hand.Card {
+	x := r.Float64() * d.total
+	i := sort.Search(len(d.cumulative), func(i int) bool { return d.cumulative[i] > x })
+	if i == len(d.combos) {
+		i--
+	}
+	return d.combos[i].Cards
+}
+
+// simulateBatch deals the trials of the plan with the random source.
+func simulateBatch(ctx context.Context, p *dealPlan, r *rand.Rand, trials int) (*tally, error) {
+	s := p.spot
+	e := newEvaluator(s)
+	t := newTally(len(s.Players))
+	deal := 2*len(p.unknown) + 5 - len(s.Board)
+	board := append(make([]hand.Card, 0, 5), s.Board...)
+	deck := make([]hand.Card, 0, len(p.live))
+	for n := 0; n < trials; n++ {
+		if n%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		used, err := p.dealRanges(e, r)
+		if err != nil {
+			return nil, err
+		}
+		deck = deck[:0]
+		for _, c := range p.live {
+			if !used.Contains(c) {
+				deck = append(deck, c)
+			}
+		}
+		// partially shuffle the deck to deal from the front
+		for i := 0; i < deal; i++ {
+			j := i + r.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+		for i, player := range p.unknown {
+			e.deal(player, deck[2*i], deck[2*i+1])
+		}
+		board = append(board[:len(s.Board)], deck[2*len(p.unknown):deal]...)
+		e.values(board, t.values)
+		t.showdown(t.values)
+	}
+	return t, nil
+}
+
+// dealRanges deals hole cards to the players with ranges and returns the
+// cards dealt.  Hole cards that share cards are rejected and every range
+// is sampled again so that the hole cards are dealt in proportion to
+// their combined weights.
+func (p *dealPlan) dealRanges(e *evaluator, r *rand.Rand) (hand.CardSet, error) {
+	if len(p.ranges) == 0 {
+		return 0, nil
+	}
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var used hand.CardSet
+		ok := true
+		for _, d := range p.ranges {
+			cards := d.sample(r)
+			if used.Contains(cards[0]) || used.Contains(cards[1]) {
+				ok = false
+				break
+			}
+			used.Add(cards[0], cards[1])
+			e.deal(d.player, cards[0], cards[1])
+		}
+		if ok {
+			return used, nil
+		}
+	}
+	return 0, ErrRangeConflict
+}
+
+func maxStdErr(t *tally) float64 {
+	max := 0.0
+	for i := range t.players {
+		max = math.Max(max, t.stdErr(i))
+	}
+	return max
+}
+
+// newEstimate returns the Estimate of the trials with intervals at the
+// confidence level.
+func newEstimate(t *tally, confidence float64, converged bool) *Estimate {
+	est := &Estimate{
+		Result:     *t.result(),
+		StdErrs:    make([]float64, len(t.players)),
+		Intervals:  make([]Interval, len(t.players)),
+		Confidence: confidence,
+		Converged:  converged,
+	}
+	z := math.Sqrt2 * math.Erfinv(confidence)
+	for i, p := range est.Players {
+		se := t.stdErr(i)
+		est.StdErrs[i] = se
+		est.Intervals[i] = Interval{
+			Low:  math.Max(p.Equity-z*se, 0),
+			High: math.Min(p.Equity+z*se, 1),
+		}
+	}
+	return est
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// RangeVsRange estimates the equity of each range against the others on
+// the board in the same way as Simulate.
+func RangeVsRange(ctx context.Context, ranges []*Range, board []hand.Card, options ...func(*Config)) (*Estimate, error) {
+	s := Spot{
+		Players: make([][]hand.Card, len(ranges)),
+		Ranges:  ranges,
+		Board:   board,
+	}
+	return Simulate(ctx, s, options...)
+}
+
+// HandVsRange estimates the equity of the hole cards against each of the
+// ranges on the board in the same way as Simulate.  The hole cards are
+// the first player of the Estimate.
+func HandVsRange(ctx context.Context, hole []hand.Card, ranges []*Range, board []hand.Card, options ...func(*Config)) (*Estimate, error) {
+	s := Spot{
+		Players: append([][]hand.Card{hole}, make([][]hand.Card, len(ranges))...),
+		Ranges:  append([]*Range{nil}, ranges...),
+		Board:   board,
+	}
+	return Simulate(ctx, s, options...)
+}
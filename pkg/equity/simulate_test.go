@@ -0,0 +1,112 @@
+package equity_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestSimulate(t *testing.T) {
+	spot := equity.Spot{
+		Players: [][]hand.Card{Cards("Ah", "Kh"), Cards("Qs", "Qd"), Cards("9c", "8c")},
+		Board:   Cards("Qh", "Jh", "2c"),
+	}
+	exact, err := equity.Enumerate(context.Background(), spot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	est, err := equity.Simulate(context.Background(), spot, equity.Trials(20000), equity.Seed(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if est.Runouts != 20000 || est.Converged {
+		t.Fatalf("expected 20000 trials without converging got %d %v", est.Runouts, est.Converged)
+	}
+	for i, p := range est.Players {
+		expected := exact.Players[i].Equity
+		if math.Abs(p.Equity-expected) > 4*est.StdErrs[i] {
+			t.Fatalf("expected player %d equity near %v got %v ± %v", i, expected, p.Equity, est.StdErrs[i])
+		}
+		if in := est.Intervals[i]; in.Low > p.Equity || in.High < p.Equity {
+			t.Fatalf("expected player %d interval %+v to contain %v", i, in, p.Equity)
+		}
+	}
+}
+
+func TestSimulateWorkers(t *testing.T) {
+	spot := equity.Spot{
+		Players: [][]hand.Card{Cards("As", "Ks"), {}, {}},
+	}
+	var expected *equity.Estimate
+	for _, workers := range []int{1, 3, 8} {
+		est, err := equity.Simulate(context.Background(), spot,
+			equity.Trials(10500), equity.Workers(workers), equity.Seed(42))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected == nil {
+			expected = est
+			continue
+		}
+		if !reflect.DeepEqual(expected, est) {
+			t.Fatalf("expected the same estimate with %d workers got %+v and %+v", workers, expected, est)
+		}
+	}
+}
+
+func TestSimulateTargetStdErr(t *testing.T) {
+	spot := equity.Spot{
+		Players: [][]hand.Card{Cards("Ah", "Ad"), {}},
+	}
+	est, err := equity.Simulate(context.Background(), spot,
+		equity.Trials(1000000), equity.TargetStdErr(0.005), equity.Seed(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !est.Converged || est.Runouts >= 1000000 || est.StdErrs[0] > 0.005 {
+		t.Fatalf("expected to converge early got %d trials with standard error %v", est.Runouts, est.StdErrs[0])
+	}
+	// aces win about 85% against a random hand
+	if math.Abs(est.Players[0].Equity-0.852) > 0.02 {
+		t.Fatalf("expected equity near 0.852 got %v", est.Players[0].Equity)
+	}
+}
+
+func TestSimulateCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	spot := equity.Spot{Players: [][]hand.Card{Cards("Ah", "Ad"), {}}}
+	if _, err := equity.Simulate(ctx, spot, equity.Seed(1)); err != context.Canceled {
+		t.Fatalf("expected %v got %v", context.Canceled, err)
+	}
+}
+
+func TestSimulateNotEnoughCards(t *testing.T) {
+	tests := []struct {
+		spot equity.Spot
+		err  error
+	}{
+		{equity.Spot{
+			Players: [][]hand.Card{Cards("Ah", "Kh"), Cards("2c", "3c")},
+			Dead:    deadExcept(2, Cards("Ah", "Kh", "2c", "3c")...),
+		}, equity.ErrNotEnoughCards},
+		{equity.Spot{
+			Players: [][]hand.Card{Cards("Ah", "Kh"), {}},
+			Dead:    deadExcept(6, Cards("Ah", "Kh")...),
+		}, equity.ErrNotEnoughCards},
+		{equity.Spot{
+			Players: [][]hand.Card{Cards("Ah", "Kh"), {}},
+			Dead:    deadExcept(7, Cards("Ah", "Kh")...),
+		}, nil},
+	}
+	for _, test := range tests {
+		if _, err := equity.Simulate(context.Background(), test.spot, equity.Trials(100), equity.Seed(1)); err != test.err {
+			t.Fatalf("expected %v got %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package equity
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+// ErrStrengthBoard is returned when hand strength is calculated without a
+// flop, turn, or river.
+var ErrStrengthBoard = errors.New("equity: hand strength requires a board of three to five cards")
+
+// outcomes of a hand against an opponent's hand
+const (
+	ahead = iota
+	tied
+	behind
+)
+
+// Strength holds the hand strength metrics of hole cards on a board
+// against a number of opponents holding random hands.
+//
+// HS is the probability of being ahead now, counting ties as half, raised
+// to the power of the number of opponents.  PPot is the probability of
+// being ahead on the river when behind now and NPot is the probability of
+// being behind on the river when ahead now, both against one opponent.
+// EHS is the effective hand strength HS*(1-NPot) + (1-HS)*PPot and EHS2
+// is the expected square of the hand strength on the river.
+type Strength struct {
+	HS   float64
+	PPot float64
+	NPot float64
+	EHS  float64
+	EHS2 float64
+}
+
+// potential accumulates the outcomes against opponents now and on the
+// river.
+type potential struct {
+	hp      [3][3]float64
+	now     [3]float64
+	ehs2    float64
+	runouts float64
+}
+
+// hs returns the hand strength against the opponents now.
+func (p *potential) hs(opponents int) float64 {
+	n := p.now[ahead] + p.now[tied] + p.now[behind]
+	return math.Pow((p.now[ahead]+p.now[tied]/2)/n, float64(opponents))
+}
+
+// strength returns the metrics with the hand strength hs.
+func (p *potential) strength(hs float64) *Strength {
+	s := &Strength{HS: hs}
+	behindTotal := p.hpTotal(behind) + p.hpTotal(tied)/2
+	if behindTotal > 0 {
+		s.PPot = (p.hp[behind][ahead] + p.hp[behind][tied]/2 + p.hp[tied][ahead]/2) / behindTotal
+	}
+	aheadTotal := p.hpTotal(ahead) + p.hpTotal(tied)/2
+	if aheadTotal > 0 {
+		s.NPot = (p.hp[ahead][behind] + p.hp[tied][behind]/2 + p.hp[ahead][tied]/2) / aheadTotal
+	}
+	s.EHS = s.HS*(1-s.NPot) + (1-s.HS)*s.PPot
+	s.EHS2 = s.HS * s.HS
+	if p.runouts > 0 {
+		s.EHS2 = p.ehs2 / p.runouts
+	}
+	return s
+}
+
+func (p *potential) hpTotal(now int) float64 {
+	return p.hp[now][ahead] + p.hp[now][tied] + p.hp[now][behind]
+}
+
+func outcome(ours, theirs int) int {
+	switch {
+	case ours > theirs:
+		return ahead
+	case ours == theirs:
+		return tied
+	}
+	return behind
+}
+
+// share returns the share of the pot of an outcome.
+func share(o int) float64 {
+	return []float64{1, 0.5, 0}[o]
+}
+
+// strengthSpot validates the hole cards and board for hand strength and
+// returns the spot with an unknown opponent.
+func strengthSpot(hole, board []hand.Card, opponents int, g hand.GameType) (Spot, error) {
+	s := Spot{Players: [][]hand.Card{hole, nil}, Board: board, GameType: g}
+	if opponents < 1 || opponents > 9 {
+		return s, ErrInvalidPlayerCount
+	}
+	if err := s.validate(true); err != nil {
+		return s, err
+	}
+	if len(board) < 3 {
+		return s, ErrStrengthBoard
+	}
+	return s, nil
+}
+
+func value(g hand.GameType, hole []hand.Card, board []hand.Card) int {
+	cards := append(append(make([]hand.Card, 0, 7), hole...), board...)
+	return hand.New(cards, hand.WithGameType(g)).Value()
+}
+
+// ExactStrength calculates the hand strength metrics of the hole cards on
+// the board by enumerating every opponent hand and every runout.  Hands
+// are compared with the ranking scheme of the game type.  ExactStrength
+// returns the context's error if it's cancelled.
+func ExactStrength(ctx context.Context, hole, board []hand.Card, opponents int, g hand.GameType) (*Strength, error) {
+	s, err := strengthSpot(hole, board, opponents, g)
+	if err != nil {
+		return nil, err
+	}
+	live := s.live()
+	type opponent struct {
+		cards hand.CardSet
+		hole  []hand.Card
+		now   int
+	}
+	opps := []opponent{}
+	ours := value(g, hole, board)
+	p := &potential{}
+	util.ForEachCombination(len(live), 2, func(idx []int) bool {
+		o := opponent{hole: []hand.Card{live[idx[0]], live[idx[1]]}}
+		o.cards = hand.NewCardSet(o.hole...)
+		o.now = outcome(ours, value(g, o.hole, board))
+		p.now[o.now]++
+		opps = append(opps, o)
+		return true
+	})
+	if len(board) == 5 {
+		return p.strength(p.hs(opponents)), nil
+	}
+
+	river := append(make([]hand.Card, 0, 5), board...)
+	util.ForEachCombination(len(live), 5-len(board), func(idx []int) bool {
+		if err = ctx.Err(); err != nil {
+			return false
+		}
+		river = river[:len(board)]
+		var runout hand.CardSet
+		for _, i := range idx {
+			river = append(river, live[i])
+			runout.Add(live[i])
+		}
+		ours := value(g, hole, river)
+		var now [3]float64
+		for _, o := range opps {
+			if o.cards.Overlaps(runout) {
+				continue
+			}
+			later := outcome(ours, value(g, o.hole, river))
+			p.hp[o.now][later]++
+			now[later]++
+		}
+		hs := (now[ahead] + now[tied]/2) / (now[ahead] + now[tied] + now[behind])
+		p.ehs2 += math.Pow(hs, 2*float64(opponents))
+		p.runouts++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.strength(p.hs(opponents)), nil
+}
+
+// SampleStrength estimates the hand strength metrics of the hole cards on
+// the board from random runouts and opponent hands.  Only the Trials and
+// Seed options apply.  SampleStrength returns the context's error if it's
+// cancelled.
+func SampleStrength(ctx context.Context, hole, board []hand.Card, opponents int, g hand.GameType, options ...func(*Config)) (*Strength, error) {
+	c := &Config{trials: 100000}
+	for _, option := range options {
+		option(c)
+	}
+	if !c.seeded {
+		c.seed = time.Now().UnixNano()
+	}
+	s, err := strengthSpot(hole, board, opponents, g)
+	if err != nil {
+		return nil, err
+	}
+	r := rand.New(rand.NewSource(c.seed))
+	deck := s.live()
+	k := 5 - len(board)
+	ours := value(g, hole, board)
+	river := append(make([]hand.Card, 0, 5), board...)
+	p := &potential{}
+	hs := 0.0
+	for n := 0; n < c.trials; n++ {
+		if n%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		// deal the runout and an opponent from the front of the deck
+		for i := 0; i < k+2; i++ {
+			j := i + r.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+		river = append(river[:len(board)], deck[:k]...)
+		oursLater := value(g, hole, river)
+		opp := deck[k : k+2]
+		now := outcome(ours, value(g, opp, board))
+		p.now[now]++
+		p.hp[now][outcome(oursLater, value(g, opp, river))]++
+
+		// the product of the shares against independent opponents is an
+		// unbiased estimate of the strength raised to their number
+		product := 1.0
+		for i := 0; i < opponents; i++ {
+			product *= share(outcome(ours, value(g, sampleHole(r, deck, 0), board)))
+		}
+		hs += product
+		if k > 0 {
+			product = 1.0
+			for i := 0; i < 2*opponents && product > 0; i++ {
+				product *= share(outcome(oursLater, value(g, sampleHole(r, deck, k), river)))
+			}
+			p.ehs2 += product
+			p.runouts++
+		}
+	}
+	return p.strength(hs / float64(c.trials)), nil
+}
+
+// sampleHole returns two random cards of deck[from:].
+func sampleHole(r *rand.Rand, deck []hand.Card, from int) []hand.Card {
+	n := len(deck) - from
+	i := r.Intn(n)
+	j := r.Intn(n - 1)
+	if j >= i {
+		j++
+	}
+	return []hand.Card{deck[from+i], deck[from+j]}
+}
@@ -0,0 +1,65 @@
+package equity_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/notnil/joker/pkg/equity"
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestExactStrength(t *testing.T) {
+	// the example from Billings et al. "The challenge of poker"
+	s, err := equity.ExactStrength(context.Background(), Cards("Ad", "Qc"), Cards("3h", "4c", "Jh"), 1, hand.GameTypeStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := equity.Strength{HS: 0.585, PPot: 0.208, NPot: 0.274}
+	if math.Abs(s.HS-expected.HS) > 0.001 || math.Abs(s.PPot-expected.PPot) > 0.001 || math.Abs(s.NPot-expected.NPot) > 0.001 {
+		t.Fatalf("expected %+v got %+v", expected, s)
+	}
+	if math.Abs(s.EHS-(s.HS*(1-s.NPot)+(1-s.HS)*s.PPot)) > 1e-9 {
+		t.Fatalf("expected EHS from HS and potentials got %+v", s)
+	}
+}
+
+func TestStrengthRiver(t *testing.T) {
+	s, err := equity.ExactStrength(context.Background(), Cards("As", "Ah"), Cards("Ad", "Ac", "Kd", "2s", "7h"), 3, hand.GameTypeStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *s != (equity.Strength{HS: 1, EHS: 1, EHS2: 1}) {
+		t.Fatalf("expected the nuts got %+v", s)
+	}
+}
+
+func TestSampleStrength(t *testing.T) {
+	hole, board := Cards("8s", "9s"), Cards("7s", "6h", "Kd", "Qc")
+	for _, opponents := range []int{1, 2} {
+		for _, g := range []hand.GameType{hand.GameTypeStandard, hand.GameTypeShortDeck} {
+			exact, err := equity.ExactStrength(context.Background(), hole, board, opponents, g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sampled, err := equity.SampleStrength(context.Background(), hole, board, opponents, g, equity.Trials(20000), equity.Seed(1))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(exact.HS-sampled.HS) > 0.02 || math.Abs(exact.PPot-sampled.PPot) > 0.03 ||
+				math.Abs(exact.NPot-sampled.NPot) > 0.03 || math.Abs(exact.EHS2-sampled.EHS2) > 0.02 {
+				t.Fatalf("expected %+v got %+v", exact, sampled)
+			}
+		}
+	}
+}
+
+func TestStrengthErrors(t *testing.T) {
+	if _, err := equity.ExactStrength(context.Background(), Cards("As", "Ah"), nil, 1, hand.GameTypeStandard); err != equity.ErrStrengthBoard {
+		t.Fatalf("expected %v got %v", equity.ErrStrengthBoard, err)
+	}
+	if _, err := equity.SampleStrength(context.Background(), Cards("As", "Ah"), Cards("Kd", "2s", "7h"), 10, hand.GameTypeStandard); err != equity.ErrInvalidPlayerCount {
+		t.Fatalf("expected %v got %v", equity.ErrInvalidPlayerCount, err)
+	}
+}
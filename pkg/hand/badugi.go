@@ -0,0 +1,183 @@
+package hand
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Badugi rankings follow the standard rankings so that their values don't
+// collide with them, and like badugi hands they are ordered from the best
+// to the worst.
+const (
+	// BadugiFourCard represents a badugi hand of four cards of different
+	// ranks and suits.
+	// Ex: 8♠ 5♥ 3♦ A♣
+	BadugiFourCard Ranking = StdFiveOfAKind + 1 + iota
+
+	// BadugiThreeCard represents a badugi hand of three cards of different
+	// ranks and suits.
+	// Ex: 8♠ 5♥ 3♦
+	BadugiThreeCard
+
+	// BadugiTwoCard represents a badugi hand of two cards of different
+	// ranks and suits.
+	// Ex: 5♥ 3♦
+	BadugiTwoCard
+
+	// BadugiOneCard represents a badugi hand of a single card.
+	// Ex: 3♦
+	BadugiOneCard
+)
+
+var badugiRankings = []Ranking{BadugiOneCard, BadugiTwoCard, BadugiThreeCard, BadugiFourCard}
+
+var badugiDescriptions = []DescriptionKind{DescOneCardBadugi, DescTwoCardBadugi, DescThreeCardBadugi, DescBadugi}
+
+// Badugi configures NewHand to select the best badugi hand: the most
+// cards of different ranks and suits with the lowest cards breaking
+// ties.  Aces are low and hands may be formed from any number of cards.
+// Badugi hands are low hands and are sorted with SortingLow.  Wild cards
+// and jokers are used as the lowest rank in a suit the hand is missing.
+func Badugi(c *Config) {
+	c.sorting = SortingLow
+	c.aceIsLow = true
+	c.badugi = true
+}
+
+// newBadugi returns the best badugi hand out of all subsets of cards.
+// Wild cards, which include jokers, are added to each subset of the other
+// cards as the lowest ranks it doesn't have in suits it doesn't have, and
+// bug jokers only as aces.
+func newBadugi(cards []Card, c *Config) *Hand {
+	if len(cards) == 0 || len(cards) > 16 {
+		panic("hand: badugi requires between one and sixteen cards")
+	}
+	t := tableFor(c)
+	naturals, wilds := []Card{}, []Card{}
+	for _, card := range cards {
+		if c.isWild(card) {
+			wilds = append(wilds, card)
+		} else {
+			naturals = append(naturals, card)
+		}
+	}
+	var bestValue uint16
+	var bestSubset int
+	var bestWilds []WildCard
+	for subset := 0; subset < 1<<uint(len(naturals)); subset++ {
+		var ranks, suits uint16
+		valid := true
+		for i, card := range naturals {
+			if subset&(1<<uint(i)) == 0 {
+				continue
+			}
+			r, s := uint16(1)<<uint(card.Rank()), uint16(1)<<uint(card.Suit())
+			if ranks&r != 0 || suits&s != 0 {
+				valid = false
+				break
+			}
+			ranks, suits = ranks|r, suits|s
+		}
+		if !valid {
+			continue
+		}
+		ranks, used := badugiWilds(wilds, ranks, suits, c)
+		if ranks == 0 {
+			continue
+		}
+		// natural cards are kept over wild cards used as the same hand
+		v := t.uniques[ranks]
+		if bestValue == 0 || v < bestValue || v == bestValue && len(used) < len(bestWilds) {
+			bestValue, bestSubset, bestWilds = v, subset, used
+		}
+	}
+	formed, subs := []Card{}, []Card{}
+	for i, card := range naturals {
+		if bestSubset&(1<<uint(i)) != 0 {
+			formed = append(formed, card)
+			subs = append(subs, card)
+		}
+	}
+	for _, w := range bestWilds {
+		formed = append(formed, w.Wild)
+		subs = append(subs, w.As)
+	}
+	sorted := append([]Card{}, subs...)
+	sort.Sort(sort.Reverse(byAceLow(sorted)))
+	class := t.classes[bestValue]
+	h := &Hand{
+		ranking:     class.ranking,
+		cards:       sorted,
+		description: class.description,
+		desc:        class.desc,
+		config:      c,
+		value:       int(bestValue),
+	}
+	restoreWilds(h, formed, subs)
+	return h
+}
+
+// badugiWilds adds the wild cards to a badugi with the given rank and
+// suit masks in order while it has less than four cards, each as the
+// lowest rank with aces low in the lowest suit that the badugi doesn't
+// have, and returns the new rank mask and the wild cards used.  Bug
+// jokers are only used as aces.
+func badugiWilds(wilds []Card, ranks, suits uint16, c *Config) (uint16, []WildCard) {
+	used := []WildCard{}
+	for _, w := range wilds {
+		if bits.OnesCount16(ranks) == 4 {
+			break
+		}
+		candidates := allAceLowRanks()
+		if w.IsJoker() && c.jokerBug {
+			candidates = []Rank{Ace}
+		}
+		for _, r := range candidates {
+			if ranks&(1<<uint(r)) != 0 {
+				continue
+			}
+			suit := Suit(bits.TrailingZeros16(^suits))
+			ranks, suits = ranks|1<<uint(r), suits|1<<uint(suit)
+			used = append(used, WildCard{Wild: w, As: getCard(r, suit)})
+			break
+		}
+	}
+	return ranks, used
+}
+
+// buildBadugiTable numbers every badugi rank combination in ascending
+// order of strength as a high hand, so the best badugi has the lowest
+// value, and indexes the values by rank mask in uniques.
+func buildBadugiTable() *evalTable {
+	entries := []tableEntry{}
+	for mask := uint16(1); mask < 1<<13; mask++ {
+		n := bits.OnesCount16(mask)
+		if n > 4 {
+			continue
+		}
+		e := tableEntry{mask: mask}
+		e.class.ranking = badugiRankings[n-1]
+		ranks := []Rank{}
+		for _, r := range allRanks() {
+			if mask&(1<<uint(r)) != 0 {
+				ranks = append(ranks, r)
+			}
+		}
+		sort.Sort(sort.Reverse(byAceLowRank(ranks)))
+		for i, r := range ranks {
+			e.class.ranks[i] = r
+		}
+		e.class.desc = Description{Kind: badugiDescriptions[n-1], Ranking: e.class.ranking, Ranks: ranks}
+		e.class.description = e.class.desc.String()
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return compareClasses(entries[i].class, entries[j].class, true) < 0
+	})
+	t := &evalTable{classes: []evalClass{{}}}
+	for _, e := range entries {
+		t.classes = append(t.classes, e.class)
+		t.uniques[e.mask] = uint16(len(t.classes) - 1)
+	}
+	return t
+}
@@ -0,0 +1,109 @@
+package hand_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+var badugiTests = []testOptionsPairs{
+	{
+		Cards("8s", "5h", "3d", "Ac"),
+		Cards("8s", "5h", "3d", "Ac"),
+		[]func(*hand.Config){hand.Badugi},
+		hand.BadugiFourCard,
+		"8-5-3-A badugi",
+	},
+	{
+		Cards("Kc", "Kd", "2h", "3s"),
+		Cards("Kc", "3s", "2h"),
+		[]func(*hand.Config){hand.Badugi},
+		hand.BadugiThreeCard,
+		"K-3-2 three-card hand",
+	},
+	{
+		Cards("4c", "2c", "2h", "7h"),
+		Cards("4c", "2h"),
+		[]func(*hand.Config){hand.Badugi},
+		hand.BadugiTwoCard,
+		"4-2 two-card hand",
+	},
+	{
+		Cards("Qs", "9s", "5s", "3s"),
+		Cards("3s"),
+		[]func(*hand.Config){hand.Badugi},
+		hand.BadugiOneCard,
+		"3 one-card hand",
+	},
+}
+
+func TestBadugi(t *testing.T) {
+	for _, test := range badugiTests {
+		h := hand.New(test.cards, test.options...)
+		if h.Ranking() != test.ranking {
+			t.Fatalf("expected %v got %v", test.ranking, h.Ranking())
+		}
+		if len(h.Cards()) != len(test.arrangement) {
+			t.Fatalf("expected %v got %v", test.arrangement, h.Cards())
+		}
+		for i, expected := range test.arrangement {
+			if actual := h.Cards()[i]; actual != expected {
+				t.Fatalf("expected %v got %v", expected, actual)
+			}
+		}
+		if test.description != h.Description() {
+			t.Fatalf("expected \"%v\" got \"%v\"", test.description, h.Description())
+		}
+	}
+}
+
+func TestBadugiOrder(t *testing.T) {
+	best := hand.New(Cards("As", "2h", "3d", "4c"), hand.Badugi)
+	four := hand.New(Cards("Ks", "Qh", "Jd", "Tc"), hand.Badugi)
+	three := hand.New(Cards("As", "2h", "3d", "4d"), hand.Badugi)
+	worse := hand.New(Cards("As", "2h", "5d", "4d"), hand.Badugi)
+	if best.Value() != hand.ValueCount(hand.Badugi) {
+		t.Fatalf("expected %v to have the greatest value", best)
+	}
+	hands := hand.Sort(hand.SortingLow, hand.DESC, worse, three, four, best)
+	if hands[0] != best || hands[1] != four || hands[2] != three || hands[3] != worse {
+		t.Fatalf("unexpected sort order %v", hands)
+	}
+	ranking, desc, err := hand.RankingForValue(three.Value(), hand.Badugi)
+	if err != nil || ranking != hand.BadugiThreeCard || desc != three.Description() {
+		t.Fatalf("expected value %d to be %q got %q %v", three.Value(), three.Description(), desc, err)
+	}
+	b, err := json.Marshal(three)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := &hand.Hand{}
+	if err := json.Unmarshal(b, cp); err != nil {
+		t.Fatal(err)
+	}
+	if cp.CompareTo(three) != 0 || cp.Description() != three.Description() {
+		t.Fatalf("expected %v after json round trip got %v", three, cp)
+	}
+}
+
+func TestBadugiWild(t *testing.T) {
+	tests := []struct {
+		cards       []hand.Card
+		options     []func(*hand.Config)
+		description string
+	}{
+		{hand.MustParseCards("As 2h 3d BJ"), nil, "4-3-2-A badugi (🃏 as four)"},
+		{hand.MustParseCards("Ks Kh 2h BJ"), nil, "K-2-A three-card hand (🃏 as ace)"},
+		{hand.MustParseCards("As 2h 3d BJ"), []func(*hand.Config){hand.JokerBug}, "3-2-A three-card hand"},
+		{hand.MustParseCards("5s 5h 9c 9d"), []func(*hand.Config){hand.Wild(hand.Five)}, "9-2-A three-card hand (5♥ as two, 5♠ as ace)"},
+		{hand.MustParseCards("BJ RJ"), nil, "2-A two-card hand (🂿 as two, 🃏 as ace)"},
+	}
+	for _, test := range tests {
+		h := hand.New(test.cards, append([]func(*hand.Config){hand.Badugi}, test.options...)...)
+		if h.Description() != test.description {
+			t.Fatalf("expected %q got %q", test.description, h.Description())
+		}
+	}
+}
@@ -1,10 +1,5 @@
 package hand
 
-import (
-	"errors"
-	"strings"
-)
-
 // A Rank represents the rank of a card.
 type Rank int
 
@@ -22,6 +17,9 @@ const (
 	Queen
 	King
 	Ace
+
+	// Joker is the rank of jokers, which have no natural rank.
+	Joker
 )
 
 const (
@@ -29,12 +27,15 @@ const (
 )
 
 var (
-	singularNames = []string{"two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "jack", "queen", "king", "ace"}
-	pluralNames   = []string{"twos", "threes", "fours", "fives", "sixes", "sevens", "eights", "nines", "tens", "jacks", "queens", "kings", "aces"}
+	singularNames = []string{"two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "jack", "queen", "king", "ace", "joker"}
+	pluralNames   = []string{"twos", "threes", "fours", "fives", "sixes", "sevens", "eights", "nines", "tens", "jacks", "queens", "kings", "aces", "jokers"}
 )
 
 // String returns a string in the format "2"
 func (r Rank) String() string {
+	if r == Joker {
+		return jokersStr[0]
+	}
 	return ranksStr[r : r+1]
 }
 
@@ -87,16 +88,13 @@ const (
 	Hearts
 	Diamonds
 	Clubs
+
+	// NoSuit is the suit of jokers, which have no natural suit.
+	NoSuit
 )
 
 var (
-	suitsStr = []string{"♠", "♥", "♦", "♣"}
-	suitsMap = map[string]Suit{
-		"♠": Spades,
-		"♥": Hearts,
-		"♦": Diamonds,
-		"♣": Clubs,
-	}
+	suitsStr = []string{"♠", "♥", "♦", "♣", ""}
 )
 
 // String returns a string in the format "♠"
@@ -162,24 +160,49 @@ const (
 	QueenClubs
 	KingClubs
 	AceClubs
+
+	BlackJoker
+	RedJoker
 )
 
+var jokersStr = []string{"🃏", "🂿"}
+
+// NewCard returns the card of the rank and suit.
+func NewCard(r Rank, s Suit) Card {
+	return getCard(r, s)
+}
+
 func getCard(r Rank, s Suit) Card {
 	return Card(int(r) + (13 * int(s)))
 }
 
-// Rank returns the rank of the card.
+// Rank returns the rank of the card.  Jokers are of rank Joker.
 func (c Card) Rank() Rank {
+	if c.IsJoker() {
+		return Joker
+	}
 	return Rank(c % 13)
 }
 
-// Suit returns the suit of the card.
+// Suit returns the suit of the card.  Jokers are of suit NoSuit.
 func (c Card) Suit() Suit {
+	if c.IsJoker() {
+		return NoSuit
+	}
 	return Suit(c / 13)
 }
 
-// String returns a string in the format "4♠"
+// IsJoker returns true if the card is a joker.
+func (c Card) IsJoker() bool {
+	return c == BlackJoker || c == RedJoker
+}
+
+// String returns a string in the format "4♠" or "🃏" and "🂿" for the
+// black and red jokers.
 func (c Card) String() string {
+	if c.IsJoker() {
+		return jokersStr[c-BlackJoker]
+	}
 	return c.Rank().String() + c.Suit().String()
 }
 
@@ -190,21 +213,13 @@ func (c Card) MarshalText() ([]byte, error) {
 }
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
-// The card is expected to be in the format "4♠".
+// The card may be in any format accepted by ParseCard such as "4♠".
 func (c *Card) UnmarshalText(text []byte) error {
-	s := string(text)
-	if len(s) <= 1 {
-		return errors.New("hand: invalid card text " + s)
-	}
-	rank := strings.Index(ranksStr, s[0:1])
-	if rank == -1 {
-		return errors.New("hand: invalid rank " + s[0:1])
+	card, err := ParseCard(string(text))
+	if err != nil {
+		return err
 	}
-	suit, ok := suitsMap[s[1:]]
-	if !ok {
-		return errors.New("hand: invalid suit " + s[1:])
-	}
-	*c = getCard(Rank(rank), suit)
+	*c = card
 	return nil
 }
 
@@ -268,6 +283,19 @@ func (a byAceLow) Less(i, j int) bool {
 	return iIndex < jIndex
 }
 
+// Jokers returns n jokers starting with the black joker.  There are only
+// two jokers, so n is clamped to between zero and two.
+func Jokers(n int) []Card {
+	jokers := []Card{BlackJoker, RedJoker}
+	if n < 0 {
+		n = 0
+	}
+	if n > len(jokers) {
+		n = len(jokers)
+	}
+	return jokers[:n]
+}
+
 func allRanks() []Rank {
 	return []Rank{Two, Three, Four, Five, Six, Seven, Eight,
 		Nine, Ten, Jack, Queen, King, Ace}
@@ -0,0 +1,183 @@
+package hand
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// A CardSet is a set of cards stored as a 64-bit bitset where the bit at
+// a card's value is set if the card is in the set.  The zero value is an
+// empty set.
+type CardSet uint64
+
+const (
+	// rankBits holds the bits for every rank of a single suit.
+	rankBits CardSet = 1<<13 - 1
+
+	// StandardCardSet contains all 52 cards of a standard deck.
+	StandardCardSet CardSet = 1<<52 - 1
+)
+
+// NewCardSet returns a set of the given cards.
+func NewCardSet(cards ...Card) CardSet {
+	var s CardSet
+	s.Add(cards...)
+	return s
+}
+
+// HasDuplicates returns true if any card is given more than once.
+func HasDuplicates(cards ...Card) bool {
+	var s CardSet
+	for _, c := range cards {
+		if s.Contains(c) {
+			return true
+		}
+		s.Add(c)
+	}
+	return false
+}
+
+// RankMask returns the set of the four cards of the given rank.
+func RankMask(r Rank) CardSet {
+	var s CardSet
+	for _, suit := range allSuits() {
+		s.Add(getCard(r, suit))
+	}
+	return s
+}
+
+// SuitMask returns the set of the thirteen cards of the given suit.
+func SuitMask(s Suit) CardSet {
+	return rankBits << (13 * uint(s))
+}
+
+// Add adds the cards to the set.
+func (s *CardSet) Add(cards ...Card) {
+	for _, c := range cards {
+		*s |= 1 << uint(c)
+	}
+}
+
+// Remove removes the cards from the set.
+func (s *CardSet) Remove(cards ...Card) {
+	for _, c := range cards {
+		*s &^= 1 << uint(c)
+	}
+}
+
+// Contains returns true if the card is in the set.
+func (s CardSet) Contains(c Card) bool {
+	return s&(1<<uint(c)) != 0
+}
+
+// ContainsAll returns true if every card of o is in the set.
+func (s CardSet) ContainsAll(o CardSet) bool {
+	return s&o == o
+}
+
+// Overlaps returns true if the sets have at least one card in common.
+func (s CardSet) Overlaps(o CardSet) bool {
+	return s&o != 0
+}
+
+// Union returns the set of cards in either set.
+func (s CardSet) Union(o CardSet) CardSet {
+	return s | o
+}
+
+// Intersect returns the set of cards in both sets.
+func (s CardSet) Intersect(o CardSet) CardSet {
+	return s & o
+}
+
+// Difference returns the set of cards in s that aren't in o.
+func (s CardSet) Difference(o CardSet) CardSet {
+	return s &^ o
+}
+
+// Count returns the number of cards in the set.
+func (s CardSet) Count() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// IsEmpty returns true if the set has no cards.
+func (s CardSet) IsEmpty() bool {
+	return s == 0
+}
+
+// Ranks returns a bitmask of the ranks in the set where the bit at a
+// rank's value is set if any card of that rank is in the set.
+func (s CardSet) Ranks() uint16 {
+	var ranks uint16
+	for _, suit := range allSuits() {
+		ranks |= s.SuitRanks(suit)
+	}
+	return ranks
+}
+
+// SuitRanks returns a bitmask of the ranks of the cards in the set of
+// the given suit.
+func (s CardSet) SuitRanks(suit Suit) uint16 {
+	return uint16((s >> (13 * uint(suit))) & rankBits)
+}
+
+// ForEach calls f for every card in the set in ascending order of card
+// value.  ForEach doesn't allocate.
+func (s CardSet) ForEach(f func(Card)) {
+	for s != 0 {
+		c := Card(bits.TrailingZeros64(uint64(s)))
+		s &= s - 1
+		f(c)
+	}
+}
+
+// Cards returns the cards in the set in ascending order of card value.
+func (s CardSet) Cards() []Card {
+	cards := make([]Card, 0, s.Count())
+	s.ForEach(func(c Card) {
+		cards = append(cards, c)
+	})
+	return cards
+}
+
+// Deck returns an unshuffled deck of the cards in the set.
+func (s CardSet) Deck() *Deck {
+	return &Deck{Cards: s.Cards()}
+}
+
+// String returns the cards of the set in the format "2♠,A♠,K♥"
+func (s CardSet) String() string {
+	strs := []string{}
+	s.ForEach(func(c Card) {
+		strs = append(strs, c.String())
+	})
+	return strings.Join(strs, ",")
+}
+
+// CardSet returns the set of cards remaining in the deck.
+func (d *Deck) CardSet() CardSet {
+	return NewCardSet(d.Cards...)
+}
+
+// RemoveSet removes the cards in the set from the deck in the same way
+// as Remove.
+func (d *Deck) RemoveSet(s CardSet) error {
+	return d.Remove(s.Cards()...)
+}
+
+// NewFromSet forms a hand from the cards in the set in the same way as New.
+func NewFromSet(s CardSet, options ...func(*Config)) *Hand {
+	return New(s.Cards(), options...)
+}
+
+// NewOmahaFromSets forms an Omaha hand from the hole and board cards in
+// the sets in the same way as NewOmaha.
+func NewOmahaFromSets(hole, board CardSet, options ...func(*Config)) *Hand {
+	return NewOmaha(hole.Cards(), board.Cards(), options...)
+}
+
+// NewHiLoFromSet forms the high and low hands from the cards in the set
+// in the same way as NewHiLo.
+func NewHiLoFromSet(s CardSet, options ...func(*Config)) *HiLo {
+	return NewHiLo(s.Cards(), options...)
+}
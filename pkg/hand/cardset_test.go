@@ -0,0 +1,90 @@
+package hand_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestCardSet(t *testing.T) {
+	s := hand.NewCardSet(Cards("As", "Kh", "2c")...)
+	if s.Count() != 3 || !s.Contains(hand.AceSpades) || s.Contains(hand.AceHearts) {
+		t.Fatalf("unexpected set %v", s)
+	}
+	s.Add(hand.AceHearts)
+	s.Remove(hand.KingHearts)
+	if s.String() != "A♠,A♥,2♣" {
+		t.Fatalf("expected set A♠,A♥,2♣ got %v", s)
+	}
+	o := hand.NewCardSet(Cards("Ah", "Qd")...)
+	if u := s.Union(o); u.Count() != 4 {
+		t.Fatalf("expected union of 4 cards got %v", u)
+	}
+	if i := s.Intersect(o); i != hand.NewCardSet(hand.AceHearts) {
+		t.Fatalf("expected intersection A♥ got %v", i)
+	}
+	if d := s.Difference(o); d.Contains(hand.AceHearts) || d.Count() != 2 {
+		t.Fatalf("expected difference A♠,2♣ got %v", d)
+	}
+	if !s.Overlaps(o) || s.ContainsAll(o) {
+		t.Fatalf("expected %v to overlap but not contain %v", s, o)
+	}
+	if s.Intersect(hand.RankMask(hand.Ace)).Count() != 2 {
+		t.Fatalf("expected two aces in %v", s)
+	}
+	if s.Intersect(hand.SuitMask(hand.Clubs)) != hand.NewCardSet(hand.TwoClubs) {
+		t.Fatalf("expected one club in %v", s)
+	}
+	if s.Ranks() != 1<<uint(hand.Ace)|1<<uint(hand.Two) {
+		t.Fatalf("unexpected rank mask %b", s.Ranks())
+	}
+	if s.SuitRanks(hand.Spades) != 1<<uint(hand.Ace) {
+		t.Fatalf("unexpected spade rank mask %b", s.SuitRanks(hand.Spades))
+	}
+	if hand.NewCardSet(hand.StandardCards()...) != hand.StandardCardSet {
+		t.Fatal("expected all standard cards in the standard card set")
+	}
+}
+
+func TestHasDuplicates(t *testing.T) {
+	if hand.HasDuplicates(Cards("As", "Kh", "2c")...) {
+		t.Fatal("expected no duplicates")
+	}
+	if !hand.HasDuplicates(Cards("As", "Kh", "As")...) {
+		t.Fatal("expected duplicates")
+	}
+}
+
+func TestDealerWithout(t *testing.T) {
+	dead := hand.NewCardSet(Cards("As", "Kh")...)
+	r := rand.New(rand.NewSource(0))
+	deck := hand.NewDealerWithout(r, hand.GameTypeStandard, dead).Deck()
+	if len(deck.Cards) != 50 || deck.CardSet().Overlaps(dead) {
+		t.Fatalf("expected 50 live cards got %v", deck)
+	}
+	h := hand.NewFromSet(hand.NewCardSet(Cards("As", "Ks", "Qs", "Js", "Ts", "2d")...))
+	if h.Ranking() != hand.StdRoyalFlush {
+		t.Fatalf("expected royal flush got %v", h)
+	}
+}
+
+func TestFromSets(t *testing.T) {
+	h := hand.NewOmahaFromSets(CardSet("Ah", "Ac", "7d", "8d"), CardSet("As", "Kd", "Qd", "2d", "3h"))
+	if h.Description() != "flush king high" {
+		t.Fatalf("expected flush king high got %v", h)
+	}
+	hilo := hand.NewHiLoFromSet(CardSet("As", "2d", "3h", "4c", "8s", "Kd", "Ks"))
+	if !hilo.HasLow() || hilo.High.Ranking() != hand.StdPair {
+		t.Fatalf("expected a pair of kings and a low got %v", hilo)
+	}
+	deck := hand.NewDealer(rand.New(rand.NewSource(0)), hand.GameTypeStandard).Deck()
+	dead := CardSet("As", "Kh")
+	if err := deck.RemoveSet(dead); err != nil || len(deck.Cards) != 50 || deck.CardSet().Overlaps(dead) {
+		t.Fatalf("expected the dead cards to be removed got %v %v", deck, err)
+	}
+	if err := deck.RemoveSet(dead); err != hand.ErrCardNotInDeck {
+		t.Fatalf("expected %v got %v", hand.ErrCardNotInDeck, err)
+	}
+}
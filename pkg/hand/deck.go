@@ -1,6 +1,7 @@
 package hand
 
 import (
+	"errors"
 	"math/rand"
 	"strings"
 )
@@ -13,31 +14,230 @@ const (
 	GameTypeShortDeck
 )
 
-// Deck is a slice of cards used for dealing
+var (
+	// ErrEmptyDeck is returned when a deck doesn't have enough cards.
+	ErrEmptyDeck = errors.New("hand: deck doesn't have enough cards")
+
+	// ErrCardNotInDeck is returned when a card that isn't in a deck is
+	// drawn or removed from it.
+	ErrCardNotInDeck = errors.New("hand: card isn't in the deck")
+
+	// ErrInvalidJokers is returned by NewDealerWithJokers if the number of
+	// jokers isn't one or two.
+	ErrInvalidJokers = errors.New("hand: a deck must have one or two jokers")
+)
+
+// Deck is a slice of cards used for dealing.  Cards are dealt from the
+// end of Cards.  Every card taken from the deck is recorded in its log.
 type Deck struct {
-	Cards []Card
+	Cards  []Card
+	burned []Card
+	log    []DeckEvent
+}
+
+// A DeckAction is the way a card left a deck.
+type DeckAction int
+
+const (
+	// Dealt is a card dealt from the top of the deck or drawn from it.
+	Dealt DeckAction = iota
+
+	// Burned is a card burned from the top of the deck.
+	Burned
+
+	// Removed is a dead or exposed card removed from the deck.
+	Removed
+)
+
+var deckActionNames = []string{"dealt", "burned", "removed"}
+
+// String returns the name of the action such as "burned".
+func (a DeckAction) String() string {
+	return deckActionNames[a]
+}
+
+// A DeckEvent records a card that left a deck.
+type DeckEvent struct {
+	Card   Card
+	Action DeckAction
+}
+
+// String returns a string in the format "burned 4♠".
+func (e DeckEvent) String() string {
+	return e.Action.String() + " " + e.Card.String()
 }
 
 // Pop removes a card from the deck and returns it.  Pop
 // panics if no cards are available.
 func (d *Deck) Pop() Card {
-	last := len(d.Cards) - 1
-	card := d.Cards[last]
-	d.Cards = d.Cards[:last]
+	card, err := d.TryPop()
+	if err != nil {
+		panic(err)
+	}
 	return card
 }
 
+// TryPop is like Pop but returns ErrEmptyDeck instead of panicking.
+func (d *Deck) TryPop() (Card, error) {
+	card, err := d.take()
+	if err != nil {
+		return 0, err
+	}
+	d.log = append(d.log, DeckEvent{Card: card, Action: Dealt})
+	return card, nil
+}
+
 // PopMulti calls the Pop function on n number of cards.  PopMulti
 // panics if n is larger than the number of cards in the deck.
 func (d *Deck) PopMulti(n int) []Card {
+	cards, err := d.TryPopMulti(n)
+	if err != nil {
+		panic(err)
+	}
+	return cards
+}
+
+// TryPopMulti is like PopMulti but returns ErrEmptyDeck instead of
+// panicking.  No cards are removed if there aren't enough.
+func (d *Deck) TryPopMulti(n int) ([]Card, error) {
 	if n > len(d.Cards) {
-		panic("deck doesn't have enough cards")
+		return nil, ErrEmptyDeck
 	}
 	cards := make([]Card, n)
 	for i := 0; i < n; i++ {
 		cards[i] = d.Pop()
 	}
-	return cards
+	return cards, nil
+}
+
+// Burn removes the top card of the deck face down and returns it.  Burn
+// panics if no cards are available.
+func (d *Deck) Burn() Card {
+	card, err := d.TryBurn()
+	if err != nil {
+		panic(err)
+	}
+	return card
+}
+
+// TryBurn is like Burn but returns ErrEmptyDeck instead of panicking.
+func (d *Deck) TryBurn() (Card, error) {
+	card, err := d.take()
+	if err != nil {
+		return 0, err
+	}
+	d.burned = append(d.burned, card)
+	d.log = append(d.log, DeckEvent{Card: card, Action: Burned})
+	return card, nil
+}
+
+// Peek returns the top card of the deck without removing it.  Peek
+// panics if no cards are available.
+func (d *Deck) Peek() Card {
+	card, err := d.TryPeek()
+	if err != nil {
+		panic(err)
+	}
+	return card
+}
+
+// TryPeek is like Peek but returns ErrEmptyDeck instead of panicking.
+func (d *Deck) TryPeek() (Card, error) {
+	if len(d.Cards) == 0 {
+		return 0, ErrEmptyDeck
+	}
+	return d.Cards[len(d.Cards)-1], nil
+}
+
+// Draw removes the card from wherever it is in the deck as if it were
+// dealt, which is useful for setting up scenarios.  Draw panics if the
+// card isn't in the deck.
+func (d *Deck) Draw(c Card) {
+	if err := d.TryDraw(c); err != nil {
+		panic(err)
+	}
+}
+
+// TryDraw is like Draw but returns ErrCardNotInDeck instead of
+// panicking.
+func (d *Deck) TryDraw(c Card) error {
+	if !d.remove(c) {
+		return ErrCardNotInDeck
+	}
+	d.log = append(d.log, DeckEvent{Card: c, Action: Dealt})
+	return nil
+}
+
+// Remove removes dead or exposed cards from the deck.  If any of the
+// cards isn't in the deck ErrCardNotInDeck is returned and no cards are
+// removed.
+func (d *Deck) Remove(cards ...Card) error {
+	left := append([]Card{}, d.Cards...)
+	for _, c := range cards {
+		i := indexOf(left, c)
+		if i == -1 {
+			return ErrCardNotInDeck
+		}
+		left = append(left[:i], left[i+1:]...)
+	}
+	for _, c := range cards {
+		d.remove(c)
+		d.log = append(d.log, DeckEvent{Card: c, Action: Removed})
+	}
+	return nil
+}
+
+// Contains returns true if the card is in the deck.
+func (d *Deck) Contains(c Card) bool {
+	return indexOf(d.Cards, c) != -1
+}
+
+// Remaining returns the number of cards left in the deck.
+func (d *Deck) Remaining() int {
+	return len(d.Cards)
+}
+
+// Burned returns the cards burned from the deck in the order they were
+// burned.
+func (d *Deck) Burned() []Card {
+	return append([]Card{}, d.burned...)
+}
+
+// Log returns every card that left the deck in the order it left.
+func (d *Deck) Log() []DeckEvent {
+	return append([]DeckEvent{}, d.log...)
+}
+
+// take removes the top card of the deck.
+func (d *Deck) take() (Card, error) {
+	last := len(d.Cards) - 1
+	if last < 0 {
+		return 0, ErrEmptyDeck
+	}
+	card := d.Cards[last]
+	d.Cards = d.Cards[:last]
+	return card, nil
+}
+
+// remove removes the card closest to the top of the deck that is c and
+// returns false if there is no such card.
+func (d *Deck) remove(c Card) bool {
+	i := indexOf(d.Cards, c)
+	if i == -1 {
+		return false
+	}
+	d.Cards = append(d.Cards[:i:i], d.Cards[i+1:]...)
+	return true
+}
+
+// indexOf returns the last index of c in cards or -1.
+func indexOf(cards []Card, c Card) int {
+	for i := len(cards) - 1; i >= 0; i-- {
+		if cards[i] == c {
+			return i
+		}
+	}
+	return -1
 }
 
 // String implements the fmt.Stringer interface
@@ -56,14 +256,15 @@ func (d *Deck) MarshalText() (text []byte, err error) {
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface
 func (d *Deck) UnmarshalText(text []byte) error {
-	strs := strings.Split(string(text), ",")
-	cards := make([]Card, len(strs))
-	for i, s := range strs {
-		var card *Card
-		if err := card.UnmarshalText([]byte(s)); err != nil {
-			return err
+	cards := []Card{}
+	if len(text) > 0 {
+		for _, s := range strings.Split(string(text), ",") {
+			var card Card
+			if err := card.UnmarshalText([]byte(s)); err != nil {
+				return err
+			}
+			cards = append(cards, card)
 		}
-		cards[i] = *card
 	}
 	d.Cards = cards
 	return nil
@@ -74,29 +275,99 @@ type Dealer interface {
 	Deck() *Deck
 }
 
+// A DeckSpec describes the cards of a deck: Decks copies of the cards of
+// the GameType's ranking scheme without the Removed ranks, and Jokers
+// jokers alternating between black and red.  If Cards is given the deck
+// is made of those cards instead.  For example DeckSpec{Removed:
+// []Rank{Two, Three, Four, Five, Six, Seven, Eight, Nine}} is the 20 card
+// deck of royal hold'em and DeckSpec{Decks: 2} is a shoe of two decks.
+type DeckSpec struct {
+	GameType GameType
+	Decks    int
+	Removed  []Rank
+	Jokers   int
+	Cards    []Card
+}
+
+// List returns the cards of the deck unshuffled.
+func (s DeckSpec) List() []Card {
+	if len(s.Cards) > 0 {
+		return append([]Card{}, s.Cards...)
+	}
+	var removed uint16
+	for _, r := range s.Removed {
+		removed |= 1 << uint(r)
+	}
+	deck := []Card{}
+	for _, c := range Scheme(s.GameType).Cards() {
+		if removed&(1<<uint(c.Rank())) == 0 {
+			deck = append(deck, c)
+		}
+	}
+	cards := append([]Card{}, deck...)
+	for i := 1; i < s.Decks; i++ {
+		cards = append(cards, deck...)
+	}
+	for i := 0; i < s.Jokers; i++ {
+		cards = append(cards, BlackJoker+Card(i%2))
+	}
+	return cards
+}
+
 // NewDealer returns a dealer that generates shuffled decks
 // with the given random source.
 func NewDealer(r *rand.Rand, gameType GameType) Dealer {
+	return NewDealerWithSpec(r, DeckSpec{GameType: gameType})
+}
+
+// NewDealerWithout returns a dealer that generates shuffled decks
+// with the given random source that don't contain the dead cards.
+func NewDealerWithout(r *rand.Rand, gameType GameType, dead CardSet) Dealer {
+	return dealer{
+		r:    r,
+		spec: DeckSpec{GameType: gameType},
+		dead: dead,
+	}
+}
+
+// NewDealerWithJokers returns a dealer that generates shuffled decks
+// with the given random source that include the given number of jokers.
+// ErrInvalidJokers is returned if the number of jokers isn't one or two;
+// use a DeckSpec for shoes with more jokers.
+func NewDealerWithJokers(r *rand.Rand, gameType GameType, jokers int) (Dealer, error) {
+	if jokers < 1 || jokers > 2 {
+		return nil, ErrInvalidJokers
+	}
+	return NewDealerWithSpec(r, DeckSpec{GameType: gameType, Jokers: jokers}), nil
+}
+
+// NewDealerWithSpec returns a dealer that generates shuffled decks of
+// the cards described by the spec with the given random source.  Hands
+// dealt from a spec of more than one deck should be formed with the
+// MultiDeck option.
+func NewDealerWithSpec(r *rand.Rand, spec DeckSpec) Dealer {
 	return dealer{
-		r:        r,
-		gameType: gameType,
+		r:    r,
+		spec: spec,
 	}
 }
 
 type dealer struct {
-	r        *rand.Rand
-	gameType GameType
+	r    *rand.Rand
+	spec DeckSpec
+	dead CardSet
 }
 
 func (d dealer) Deck() *Deck {
-	var allCards []Card
-	switch d.gameType {
-	case GameTypeShortDeck:
-		allCards = ShortDeckCards()
-	case GameTypeStandard:
-		allCards = StandardCards()
-	default:
-		allCards = StandardCards()
+	allCards := d.spec.List()
+	if !d.dead.IsEmpty() {
+		live := []Card{}
+		for _, c := range allCards {
+			if !d.dead.Contains(c) {
+				live = append(live, c)
+			}
+		}
+		allCards = live
 	}
 	cards := shuffleCards(d.r, allCards)
 	return &Deck{Cards: cards}
@@ -0,0 +1,145 @@
+package hand_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestDeckOperations(t *testing.T) {
+	deck := &hand.Deck{Cards: Cards("2c", "3c", "4c", "5c", "6c", "7c")}
+	if c := deck.Peek(); c != hand.SevenClubs || deck.Remaining() != 6 {
+		t.Fatalf("expected to peek at 7♣ got %v", c)
+	}
+	if c := deck.Burn(); c != hand.SevenClubs {
+		t.Fatalf("expected to burn 7♣ got %v", c)
+	}
+	if c := deck.Pop(); c != hand.SixClubs {
+		t.Fatalf("expected to pop 6♣ got %v", c)
+	}
+	deck.Draw(hand.ThreeClubs)
+	if err := deck.TryDraw(hand.ThreeClubs); err != hand.ErrCardNotInDeck {
+		t.Fatalf("expected %v got %v", hand.ErrCardNotInDeck, err)
+	}
+	if err := deck.Remove(hand.TwoClubs, hand.AceSpades); err != hand.ErrCardNotInDeck || !deck.Contains(hand.TwoClubs) {
+		t.Fatalf("expected a failed remove to leave the deck alone got %v %v", err, deck)
+	}
+	if err := deck.Remove(hand.TwoClubs); err != nil || deck.Contains(hand.TwoClubs) {
+		t.Fatalf("expected 2♣ to be removed got %v %v", err, deck)
+	}
+	if deck.Remaining() != 2 || deck.String() != "4♣,5♣" {
+		t.Fatalf("expected 4♣ and 5♣ to remain got %v", deck)
+	}
+	if _, err := deck.TryPopMulti(3); err != hand.ErrEmptyDeck || deck.Remaining() != 2 {
+		t.Fatalf("expected %v got %v", hand.ErrEmptyDeck, err)
+	}
+	deck.PopMulti(2)
+	if _, err := deck.TryPop(); err != hand.ErrEmptyDeck {
+		t.Fatalf("expected %v got %v", hand.ErrEmptyDeck, err)
+	}
+	if _, err := deck.TryBurn(); err != hand.ErrEmptyDeck {
+		t.Fatalf("expected %v got %v", hand.ErrEmptyDeck, err)
+	}
+	if _, err := deck.TryPeek(); err != hand.ErrEmptyDeck {
+		t.Fatalf("expected %v got %v", hand.ErrEmptyDeck, err)
+	}
+	if burned := deck.Burned(); len(burned) != 1 || burned[0] != hand.SevenClubs {
+		t.Fatalf("expected 7♣ to be burned got %v", burned)
+	}
+	expected := []string{"burned 7♣", "dealt 6♣", "dealt 3♣", "removed 2♣", "dealt 5♣", "dealt 4♣"}
+	log := deck.Log()
+	if len(log) != len(expected) {
+		t.Fatalf("expected %v got %v", expected, log)
+	}
+	for i, e := range log {
+		if e.String() != expected[i] {
+			t.Fatalf("expected %v got %v", expected, log)
+		}
+	}
+	defer func() {
+		if recover() != hand.ErrEmptyDeck {
+			t.Fatal("expected Pop to panic with an empty deck")
+		}
+	}()
+	deck.Pop()
+}
+
+func TestDeckSpec(t *testing.T) {
+	tests := []struct {
+		spec   hand.DeckSpec
+		count  int
+		unique int
+	}{
+		{hand.DeckSpec{}, 52, 52},
+		{hand.DeckSpec{GameType: hand.GameTypeShortDeck}, 36, 36},
+		{hand.DeckSpec{Decks: 2}, 104, 52},
+		{hand.DeckSpec{Removed: []hand.Rank{hand.Two, hand.Three, hand.Four, hand.Five, hand.Six, hand.Seven, hand.Eight, hand.Nine}}, 20, 20},
+		{hand.DeckSpec{Decks: 2, Jokers: 3}, 107, 54},
+		{hand.DeckSpec{Decks: 6, Cards: Cards("As", "As", "Kd")}, 3, 2},
+	}
+	for _, test := range tests {
+		cards := test.spec.List()
+		if len(cards) != test.count || hand.NewCardSet(cards...).Count() != test.unique {
+			t.Fatalf("expected %d cards with %d unique for %+v got %v", test.count, test.unique, test.spec, cards)
+		}
+	}
+	r := rand.New(rand.NewSource(0))
+	deck := hand.NewDealerWithSpec(r, hand.DeckSpec{Decks: 2}).Deck()
+	if len(deck.Cards) != 104 || !hand.HasDuplicates(deck.Cards...) {
+		t.Fatalf("expected a shuffled shoe of two decks got %v", deck)
+	}
+	deck = SpecDealer(hand.DeckSpec{Cards: Cards("As", "Kd", "2c")}).Deck()
+	if deck.Pop() != hand.AceSpades || deck.Pop() != hand.KingDiamonds {
+		t.Fatalf("expected the spec's cards in order got %v", deck)
+	}
+}
+
+func TestMultiDeckHands(t *testing.T) {
+	tests := []struct {
+		cards       []hand.Card
+		options     []func(*hand.Config)
+		ranking     hand.Ranking
+		description string
+		arrangement []hand.Card
+	}{
+		{Cards("As", "Ks", "As", "Qs", "Js"), nil, hand.StdFlush, "flush ace high", Cards("As", "As", "Ks", "Qs", "Js")},
+		{Cards("Ks", "As", "Ks", "Qs", "Js"), nil, hand.StdFlush, "flush ace high", Cards("As", "Ks", "Ks", "Qs", "Js")},
+		{Cards("Ah", "Ad", "Ac", "As", "As"), nil, hand.StdFiveOfAKind, "five of a kind aces", Cards("Ah", "Ad", "Ac", "As", "As")},
+		{Cards("Ks", "Ks", "Ks", "9s", "9s"), nil, hand.StdFullHouse, "full house kings full of nines", Cards("Ks", "Ks", "Ks", "9s", "9s")},
+		{Cards("Ks", "Ks", "Ks", "9s", "9s"), []func(*hand.Config){hand.ShortDeck}, hand.SDFlush, "flush king high", Cards("Ks", "Ks", "Ks", "9s", "9s")},
+		{Cards("Ks", "2h", "Ks", "Qs", "Js", "9s", "3c"), nil, hand.StdFlush, "flush king high", Cards("Ks", "Ks", "Qs", "Js", "9s")},
+		{Cards("As", "As", "Ks"), nil, hand.StdPair, "pair of aces", Cards("As", "As", "Ks")},
+	}
+	for _, test := range tests {
+		h := hand.New(test.cards, test.options...)
+		if h.Ranking() != test.ranking || h.Description() != test.description {
+			t.Fatalf("expected %v %q got %v %q", test.ranking, test.description, h.Ranking(), h.Description())
+		}
+		for i, c := range h.Cards() {
+			if c != test.arrangement[i] {
+				t.Fatalf("expected %v got %v", test.arrangement, h.Cards())
+			}
+		}
+	}
+
+	// hands are compared card by card so a paired flush can beat a
+	// flush with a higher first card
+	pairs := [][2][]hand.Card{
+		{Cards("As", "As", "Ks", "Qs", "Js"), Cards("Ah", "Kh", "Qh", "Jh", "9h")},
+		{Cards("As", "Ks", "Ks", "Qs", "Js"), Cards("Ah", "Kh", "Qh", "Jh", "9h")},
+		{Cards("Ah", "Ad", "Ac", "As", "As"), Cards("Ah", "Kh", "Qh", "Jh", "Th")},
+		{Cards("Qs", "Qs", "Qs", "Qd", "2c"), Cards("Js", "Js", "Js", "Jd", "Ac")},
+	}
+	for _, p := range pairs {
+		a := hand.New(p[0], hand.MultiDeck)
+		b := hand.New(p[1], hand.MultiDeck)
+		if a.Value() <= b.Value() || a.CompareTo(b) <= 0 {
+			t.Fatalf("expected %v to beat %v", a, b)
+		}
+	}
+	if n := hand.ValueCount(hand.MultiDeck); n <= hand.ValueCount() {
+		t.Fatalf("expected more values with duplicate cards got %d", n)
+	}
+}
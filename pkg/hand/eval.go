@@ -0,0 +1,428 @@
+package hand
+
+import (
+	"math/bits"
+	"sort"
+	"sync"
+)
+
+// rankPrimes assigns a prime to every rank so that the product of a five
+// card hand's primes uniquely identifies its multiset of ranks.
+var rankPrimes = [...]uint32{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41}
+
+// evalClass is an equivalence class of five card hands.  Every hand in
+// the class shares the same ranking, formed rank order, and description.
+type evalClass struct {
+	ranking     Ranking
+	ranks       [5]Rank
+	description string
+	desc        Description
+	straight    bool
+	flush       bool
+}
+
+// evalTable holds the precomputed lookup tables used to evaluate hands
+// for a single configuration.  A value of zero is never assigned to a
+// class, values increase with the strength of the hand, and classes is
+// indexed by value.
+type evalTable struct {
+	flushes  [1 << 13]uint16
+	uniques  [1 << 13]uint16
+	products map[uint32]uint16
+	classes  []evalClass
+
+	// flushProducts holds the flushes with paired ranks that are only
+	// possible with duplicate cards and is nil otherwise.
+	flushProducts map[uint32]uint16
+
+	// values maps table values to the values of hands dealt from the game
+	// type's deck, which skip the classes that need a rank the deck
+	// doesn't have, such as a two in short deck.  Classes the deck can't
+	// make map to zero.  values is nil if the deck has every rank and
+	// indexes is its inverse.
+	values  []uint16
+	indexes []uint16
+}
+
+// tableKey holds the Config fields that change how hands are ranked.
+type tableKey struct {
+	gameType        GameType
+	ignoreStraights bool
+	ignoreFlushes   bool
+	aceIsLow        bool
+	deuceToSeven    bool
+	badugi          bool
+	wild            bool
+	multiDeck       bool
+}
+
+func newTableKey(c *Config) tableKey {
+	schemesMu.RLock()
+	gameType := schemeType(c.gameType)
+	schemesMu.RUnlock()
+	return tableKey{
+		gameType:        gameType,
+		ignoreStraights: c.ignoreStraights,
+		ignoreFlushes:   c.ignoreFlushes,
+		aceIsLow:        c.aceIsLow,
+		deuceToSeven:    c.deuceToSeven,
+		badugi:          c.badugi,
+		wild:            c.hasWilds(),
+		multiDeck:       c.multiDeck,
+	}
+}
+
+var (
+	tablesMu sync.RWMutex
+	tables   = map[tableKey]*evalTable{}
+)
+
+// tableFor returns the lookup tables for the configuration, building
+// them on first use.  Tables are built without holding tablesMu because
+// the Valid funcs of a registered scheme may form hands themselves.  If
+// two goroutines build the same table the first one stored is kept.
+func tableFor(c *Config) *evalTable {
+	key := newTableKey(c)
+	tablesMu.RLock()
+	t, ok := tables[key]
+	tablesMu.RUnlock()
+	if ok {
+		return t
+	}
+	if c.badugi {
+		t = buildBadugiTable()
+	} else {
+		t = buildTable(*c)
+	}
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	if stored, ok := tables[key]; ok {
+		return stored
+	}
+	tables[key] = t
+	return t
+}
+
+// tableEntry is a representative five card hand for one of the table
+// slots along with its classification.
+type tableEntry struct {
+	flush bool
+	mask  uint16
+	prod  uint32
+	class evalClass
+}
+
+// buildTable classifies a representative of every five card rank
+// multiset (and every flush) with handForFiveCards and numbers the
+// resulting classes in ascending order of strength.  Five of a kind is
+// only possible, and only numbered, if the configuration has wild cards
+// or duplicate cards, and flushes with paired ranks only if it has
+// duplicate cards.
+func buildTable(c Config) *evalTable {
+	entries := []tableEntry{}
+	counts := make([]int, len(rankPrimes))
+	maxCount := 4
+	if c.hasWilds() || c.multiDeck {
+		maxCount = 5
+	}
+	var walk func(r, left int)
+	walk = func(r, left int) {
+		if left == 0 {
+			if e, ok := classifyCounts(counts, false, c); ok {
+				entries = append(entries, e)
+			}
+			if distinctRanks(counts) != 5 && !c.multiDeck {
+				return
+			}
+			if e, ok := classifyCounts(counts, true, c); ok {
+				entries = append(entries, e)
+			}
+			return
+		}
+		if r < 0 {
+			return
+		}
+		for n := min(maxCount, left); n >= 0; n-- {
+			counts[r] = n
+			walk(r-1, left-n)
+		}
+		counts[r] = 0
+	}
+	walk(len(rankPrimes)-1, 5)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return compareClasses(entries[i].class, entries[j].class, c.aceIsLow) < 0
+	})
+	t := &evalTable{
+		products: map[uint32]uint16{},
+		classes:  []evalClass{{}},
+	}
+	if c.multiDeck {
+		t.flushProducts = map[uint32]uint16{}
+	}
+	for i, e := range entries {
+		if i == 0 || compareClasses(entries[i-1].class, e.class, c.aceIsLow) != 0 {
+			t.classes = append(t.classes, e.class)
+		}
+		v := uint16(len(t.classes) - 1)
+		switch {
+		case e.flush && bits.OnesCount16(e.mask) == 5:
+			t.flushes[e.mask] = v
+		case e.flush:
+			t.flushProducts[e.prod] = v
+		case bits.OnesCount16(e.mask) == 5:
+			t.uniques[e.mask] = v
+		default:
+			t.products[e.prod] = v
+		}
+	}
+	t.numberDeck(schemeRanks(c.gameType))
+	return t
+}
+
+// numberDeck numbers the classes made only of the given ranks in
+// ascending order of strength if they aren't all of the ranks.
+func (t *evalTable) numberDeck(ranks []Rank) {
+	if len(ranks) == len(rankPrimes) {
+		return
+	}
+	var deck uint16
+	for _, r := range ranks {
+		deck |= 1 << uint(r)
+	}
+	t.values = make([]uint16, len(t.classes))
+	t.indexes = []uint16{0}
+	for i, class := range t.classes[1:] {
+		var mask uint16
+		for _, r := range class.ranks {
+			mask |= 1 << uint(r)
+		}
+		if mask&^deck != 0 {
+			continue
+		}
+		t.values[i+1] = uint16(len(t.indexes))
+		t.indexes = append(t.indexes, uint16(i+1))
+	}
+}
+
+// deckValue returns the value of a hand of the class at index v as dealt
+// from the game type's deck, or zero if the deck can't make the class.
+func (t *evalTable) deckValue(v uint16) int {
+	if t.values == nil {
+		return int(v)
+	}
+	return int(t.values[v])
+}
+
+// deckClass returns the class of hands with the given value as dealt from
+// the game type's deck.  value must be between one and valueCount.
+func (t *evalTable) deckClass(value int) evalClass {
+	if t.indexes == nil {
+		return t.classes[value]
+	}
+	return t.classes[t.indexes[value]]
+}
+
+// valueCount returns the number of values hands dealt from the game
+// type's deck can have.
+func (t *evalTable) valueCount() int {
+	if t.indexes == nil {
+		return len(t.classes) - 1
+	}
+	return len(t.indexes) - 1
+}
+
+// classifyCounts builds a representative hand for the rank counts and
+// classifies it.  Configurations that can't classify the hand, such as
+// ignoring flushes while counting straights, return false.
+func classifyCounts(counts []int, flush bool, c Config) (tableEntry, bool) {
+	cards := []Card{}
+	e := tableEntry{flush: flush, prod: 1}
+	for r, n := range counts {
+		for i := 0; i < n; i++ {
+			suit := Suit(i % 4)
+			if flush {
+				suit = Spades
+			}
+			cards = append(cards, getCard(Rank(r), suit))
+			e.mask |= 1 << uint(r)
+			e.prod *= rankPrimes[r]
+		}
+	}
+	if !flush && distinctRanks(counts) == 5 {
+		// five distinct ranks dealt in spades, move one to hearts
+		cards[0] = getCard(cards[0].Rank(), Hearts)
+	}
+	h, ok := classifyCards(cards, c)
+	if !ok {
+		return e, false
+	}
+	e.class.ranking = h.ranking
+	e.class.description = h.description
+	e.class.desc = h.desc
+	e.class.straight = !c.ignoreStraights && HasStraight(h.cards, c)
+	e.class.flush = !c.ignoreFlushes && HasFlush(h.cards)
+	for i, card := range h.cards {
+		e.class.ranks[i] = card.Rank()
+	}
+	return e, true
+}
+
+func distinctRanks(counts []int) int {
+	n := 0
+	for _, c := range counts {
+		if c > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// compareClasses orders classes by ranking and then by the ranks of their
+// formed cards.  If aceIsLow is true aces are ranked below twos.
+func compareClasses(a, b evalClass, aceIsLow bool) int {
+	if a.ranking != b.ranking {
+		return int(a.ranking) - int(b.ranking)
+	}
+	for i := 0; i < 5; i++ {
+		if a.ranks[i] != b.ranks[i] {
+			if aceIsLow {
+				return a.ranks[i].aceLowIndexOf() - b.ranks[i].aceLowIndexOf()
+			}
+			return int(a.ranks[i]) - int(b.ranks[i])
+		}
+	}
+	return 0
+}
+
+// lookup returns the value of the five cards.
+func (t *evalTable) lookup(c0, c1, c2, c3, c4 Card) uint16 {
+	r0, r1, r2, r3, r4 := c0%13, c1%13, c2%13, c3%13, c4%13
+	mask := uint16(1)<<uint(r0) | uint16(1)<<uint(r1) | uint16(1)<<uint(r2) |
+		uint16(1)<<uint(r3) | uint16(1)<<uint(r4)
+	if bits.OnesCount16(mask) == 5 {
+		s := c0 / 13
+		if c1/13 == s && c2/13 == s && c3/13 == s && c4/13 == s {
+			return t.flushes[mask]
+		}
+		return t.uniques[mask]
+	}
+	prod := rankPrimes[r0] * rankPrimes[r1] * rankPrimes[r2] * rankPrimes[r3] * rankPrimes[r4]
+	if t.flushProducts != nil {
+		s := c0 / 13
+		if c1/13 == s && c2/13 == s && c3/13 == s && c4/13 == s {
+			return t.flushProducts[prod]
+		}
+	}
+	return t.products[prod]
+}
+
+// selection tracks the highest (or lowest if low is true) value out of
+// the five card combinations it considers.  The first combination found
+// wins ties.
+type selection struct {
+	t     *evalTable
+	low   bool
+	value uint16
+	cards [5]Card
+}
+
+func (s *selection) consider(c0, c1, c2, c3, c4 Card) {
+	v := s.t.lookup(c0, c1, c2, c3, c4)
+	if v == 0 {
+		panic("unreachable")
+	}
+	better := v > s.value
+	if s.low {
+		better = s.value == 0 || v < s.value
+	}
+	if better {
+		s.value = v
+		s.cards = [5]Card{c0, c1, c2, c3, c4}
+	}
+}
+
+// forEachCombo calls f with every five card combination of cards in the
+// order the cards were given.  forEachCombo doesn't allocate.
+func forEachCombo(cards []Card, f func(c0, c1, c2, c3, c4 Card)) {
+	n := len(cards)
+	for a := 0; a < n-4; a++ {
+		for b := a + 1; b < n-3; b++ {
+			for c := b + 1; c < n-2; c++ {
+				for d := c + 1; d < n-1; d++ {
+					for e := d + 1; e < n; e++ {
+						f(cards[a], cards[b], cards[c], cards[d], cards[e])
+					}
+				}
+			}
+		}
+	}
+}
+
+// forEachOmahaCombo calls f with every combination of exactly two hole
+// cards and three board cards.  forEachOmahaCombo doesn't allocate.
+func forEachOmahaCombo(hole, board []Card, f func(c0, c1, c2, c3, c4 Card)) {
+	for a := 0; a < len(hole)-1; a++ {
+		for b := a + 1; b < len(hole); b++ {
+			for c := 0; c < len(board)-2; c++ {
+				for d := c + 1; d < len(board)-1; d++ {
+					for e := d + 1; e < len(board); e++ {
+						f(hole[a], hole[b], board[c], board[d], board[e])
+					}
+				}
+			}
+		}
+	}
+}
+
+// best returns the highest (or lowest if low is true) value out of all
+// five card combinations of cards and the cards that form it.  cards must
+// hold at least five cards and best doesn't allocate.
+func (t *evalTable) best(cards []Card, low bool) (uint16, [5]Card) {
+	s := selection{t: t, low: low}
+	forEachCombo(cards, s.consider)
+	return s.value, s.cards
+}
+
+// bestOmaha returns the highest (or lowest if low is true) value out of
+// all combinations of exactly two hole cards and three board cards and the
+// cards that form it.  hole must hold at least two cards, board must hold
+// at least three cards and bestOmaha doesn't allocate.
+func (t *evalTable) bestOmaha(hole, board []Card, low bool) (uint16, [5]Card) {
+	s := selection{t: t, low: low}
+	forEachOmahaCombo(hole, board, s.consider)
+	return s.value, s.cards
+}
+
+// hand forms the Hand for the five cards, which make up a hand of the
+// given value.  Cards are arranged in the order of the class's ranks and
+// cards of the same rank keep the order they were given in.
+func (t *evalTable) hand(cards [5]Card, v uint16, c *Config) *Hand {
+	class := t.classes[v]
+	formed := make([]Card, 5)
+	var used [5]bool
+	for i, r := range class.ranks {
+		for j, card := range cards {
+			if !used[j] && card.Rank() == r {
+				used[j] = true
+				formed[i] = card
+				break
+			}
+		}
+	}
+	return &Hand{
+		ranking:     class.ranking,
+		cards:       formed,
+		description: class.description,
+		desc:        class.desc,
+		config:      c,
+		value:       t.deckValue(v),
+	}
+}
+
+func min(a, b int) int {
+	if a > b {
+		return b
+	}
+	return a
+}
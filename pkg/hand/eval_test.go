@@ -0,0 +1,157 @@
+package hand
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/util"
+)
+
+// legacyNew is the combination brute force that New used before the
+// lookup tables were introduced, except that aces are compared as low
+// cards if the options make them low, which the legacy comparison of
+// Sort didn't do.
+func legacyNew(cards []Card, options ...func(*Config)) *Hand {
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	var best *Hand
+	for _, combo := range util.Combinations(len(cards), 5) {
+		cCards := []Card{}
+		for _, i := range combo {
+			cCards = append(cCards, cards[i])
+		}
+		h := handForFiveCards(cCards, *c)
+		cmp := 0
+		if best != nil {
+			cmp = legacyCompare(h, best, c.aceIsLow)
+		}
+		if best == nil || c.sorting == SortingLow && cmp < 0 || c.sorting != SortingLow && cmp > 0 {
+			best = h
+		}
+	}
+	best.config = c
+	return best
+}
+
+// legacyCompare compares hands by ranking and then by the ranks of their
+// cards in order, like CompareTo without values.
+func legacyCompare(a, b *Hand, aceIsLow bool) int {
+	if a.Ranking() != b.Ranking() {
+		return int(a.Ranking()) - int(b.Ranking())
+	}
+	for i := range a.cards {
+		ar, br := int(a.cards[i].Rank()), int(b.cards[i].Rank())
+		if aceIsLow && ar == int(Ace) {
+			ar = -1
+		}
+		if aceIsLow && br == int(Ace) {
+			br = -1
+		}
+		if ar != br {
+			return ar - br
+		}
+	}
+	return 0
+}
+
+type evalTest struct {
+	name    string
+	cards   []Card
+	options []func(*Config)
+}
+
+var evalTests = []evalTest{
+	{"standard", StandardCards(), nil},
+	{"short deck", ShortDeckCards(), []func(*Config){ShortDeck}},
+	{"ace to five low", StandardCards(), []func(*Config){AceToFiveLow}},
+}
+
+func TestEvalAllFiveCardHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping exhaustive evaluation in short mode")
+	}
+	for _, test := range evalTests {
+		deck := test.cards
+		n := len(deck)
+		combo := make([]Card, 5)
+		for a := 0; a < n; a++ {
+			for b := a + 1; b < n; b++ {
+				for c := b + 1; c < n; c++ {
+					for d := c + 1; d < n; d++ {
+						for e := d + 1; e < n; e++ {
+							combo[0], combo[1], combo[2], combo[3], combo[4] = deck[a], deck[b], deck[c], deck[d], deck[e]
+							expected := legacyNew(combo, test.options...)
+							actual := New(combo, test.options...)
+							if err := sameHand(expected, actual); err != "" {
+								t.Fatalf("%s %v: %s", test.name, combo, err)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestEvalSevenCardHands(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, test := range evalTests {
+		for i := 0; i < 10000; i++ {
+			deck := shuffleCards(r, test.cards)
+			cards := deck[:5+r.Intn(3)]
+			expected := legacyNew(cards, test.options...)
+			actual := New(cards, test.options...)
+			if expected.Ranking() != actual.Ranking() ||
+				expected.Description() != actual.Description() ||
+				expected.CompareTo(actual) != 0 {
+				t.Fatalf("%s %v: expected %v got %v", test.name, cards, expected, actual)
+			}
+		}
+	}
+}
+
+func TestEvalClassCount(t *testing.T) {
+	if n := len(tableFor(&Config{}).classes) - 1; n != 7462 {
+		t.Fatalf("expected %d standard classes but got %d", 7462, n)
+	}
+}
+
+func TestEvalAllocations(t *testing.T) {
+	cards := StandardCards()[:7]
+	tbl := tableFor(&Config{})
+	allocs := testing.AllocsPerRun(100, func() {
+		tbl.best(cards, false)
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations but got %v", allocs)
+	}
+}
+
+func sameHand(expected, actual *Hand) string {
+	if expected.Ranking() != actual.Ranking() {
+		return "ranking " + expected.Ranking().String() + " != " + actual.Ranking().String()
+	}
+	if expected.Description() != actual.Description() {
+		return "description " + expected.Description() + " != " + actual.Description()
+	}
+	e, a := expected.Cards(), actual.Cards()
+	for i := range e {
+		if e[i] != a[i] {
+			return "cards " + expected.String() + " != " + actual.String()
+		}
+	}
+	return ""
+}
+
+func BenchmarkEvalSevenCards(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	cards := shuffleCards(r, StandardCards())[:7]
+	tbl := tableFor(&Config{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tbl.best(cards, false)
+	}
+}
@@ -0,0 +1,293 @@
+package hand
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FullDescription returns the description of the hand followed by the
+// kickers that break ties between hands of the same rank, such as "pair
+// of queens, king-jack-nine kickers" or "flush ace high,
+// king-nine-six-four".  Straights, full houses, and other hands without
+// kickers are described as they are by Description.  The kickers are
+// part of the structured description returned by Describe; Description
+// leaves them out so that its text stays the same.
+func (h *Hand) FullDescription() string {
+	return h.Describe().String()
+}
+
+// kickers returns the ranks of the formed cards after the ones the
+// hand's description names, with wild cards replaced by the cards they
+// were used as.  Straights, badugi hands, and text descriptions don't
+// have kickers.
+func (h *Hand) kickers() []Rank {
+	s := h.shape()
+	if s.badugi || s.straight || h.desc.Kind == DescText {
+		return nil
+	}
+	start := s.made()
+	if h.desc.Kind == DescLow {
+		start = len(h.desc.Ranks)
+	}
+	if start >= len(s.ranks) {
+		return nil
+	}
+	return append([]Rank{}, s.ranks[start:]...)
+}
+
+// A Reason is why one hand beat or tied another.
+type Reason int
+
+const (
+	// ReasonTie means the hands are of equal strength.
+	ReasonTie Reason = iota
+
+	// ReasonRanking means the winner has a better ranking, such as a
+	// flush beating a straight.
+	ReasonRanking
+
+	// ReasonRanks means the hands share a ranking but the winner's
+	// ranking is made with better ranks, such as a higher pair.
+	ReasonRanks
+
+	// ReasonKicker means the hands share a ranking made with the same
+	// ranks and the winner has a better kicker or, for hands without
+	// pairs such as flushes and lows, a better card.
+	ReasonKicker
+)
+
+// An Explanation describes why one hand beat or tied another.  Position
+// is the index of the first of the formed cards whose ranks differ and
+// WinningRank and LosingRank are the ranks of the winner and loser at
+// that position.  Kicker is the one-based number of the deciding kicker
+// or card if Reason is ReasonKicker.  Position, Kicker, and the ranks are
+// only set if Reason is ReasonRanks or ReasonKicker.
+type Explanation struct {
+	Reason      Reason
+	Winner      *Hand
+	Loser       *Hand
+	Position    int
+	Kicker      int
+	WinningRank Rank
+	LosingRank  Rank
+}
+
+// Explain compares the hands, which must be formed with the same
+// options, and returns why one won or why they tied.  If they tie
+// Winner is a and Loser is b.
+func Explain(a, b *Hand) Explanation {
+	cmp := compareHands(a, b)
+	e := Explanation{Winner: a, Loser: b}
+	if cmp < 0 {
+		e.Winner, e.Loser = b, a
+	}
+	if cmp == 0 {
+		return e
+	}
+	e.Reason = ReasonRanking
+	if a.ranking != b.ranking {
+		return e
+	}
+	ws, ls := e.Winner.shape(), e.Loser.shape()
+	for i := 0; i < len(ws.ranks) && i < len(ls.ranks); i++ {
+		if ws.ranks[i] == ls.ranks[i] {
+			continue
+		}
+		e.Position, e.WinningRank, e.LosingRank = i, ws.ranks[i], ls.ranks[i]
+		e.Reason = ReasonRanks
+		if made := ws.made(); i >= made {
+			e.Reason = ReasonKicker
+			e.Kicker = i - made + 1
+			if ws.unpaired() && (ws.flush || ws.low) {
+				e.Kicker = i + 1
+			}
+		}
+		return e
+	}
+	return e
+}
+
+// String returns the reason in English in a format such as "same pair,
+// wins on second kicker K vs J", "higher pair Q vs J", or "flush ace high
+// beats straight ten high".
+func (e Explanation) String() string {
+	return e.Render(English)
+}
+
+// Render returns the reason in the language of the locale.
+func (e Explanation) Render(l *Locale) string {
+	switch e.Reason {
+	case ReasonTie:
+		return l.phrase("tie, both have {hand}", "{hand}", e.Winner.LocalFullDescription(l))
+	case ReasonRanking:
+		return l.phrase("{winner} beats {loser}",
+			"{winner}", e.Winner.LocalDescription(l), "{loser}", e.Loser.LocalDescription(l))
+	}
+	s := e.Winner.shape()
+	ranks := []string{"{winning}", e.WinningRank.String(), "{losing}", e.LosingRank.String()}
+	better := "higher"
+	if s.low {
+		better = "lower"
+	}
+	if e.Reason == ReasonKicker {
+		unit := "kicker"
+		if s.unpaired() && (s.flush || s.low) {
+			unit = "card"
+		}
+		return l.phrase("same {name}, wins on {nth} {unit} {winning} vs {losing}", append(ranks,
+			"{name}", s.name(l),
+			"{nth}", l.phrase(ordinalNames[e.Kicker-1]),
+			"{n}", strconv.Itoa(e.Kicker),
+			"{unit}", l.phrase(unit))...)
+	}
+	ranks = append(ranks, "{better}", l.phrase(better), "{part}", l.phrase(s.part(e.Position)))
+	if first := s.part(0); e.Position > 0 && s.part(e.Position) != first {
+		return l.phrase("same {first}, {better} {part} {winning} vs {losing}", append(ranks, "{first}", l.phrase(first))...)
+	}
+	return l.phrase("{better} {part} {winning} vs {losing}", ranks...)
+}
+
+var ordinalNames = []string{"first", "second", "third", "fourth", "fifth"}
+
+// compareHands returns a positive value if a beats b, a negative value if
+// b beats a, and zero if they tie, taking low sorting into account.
+func compareHands(a, b *Hand) int {
+	if a.value != 0 && b.value != 0 && sameTable(a.config, b.config) {
+		return a.Value() - b.Value()
+	}
+	cmp := a.CompareTo(b)
+	if a.config != nil && a.config.sorting == SortingLow {
+		return -cmp
+	}
+	return cmp
+}
+
+// handShape is the structure of a formed hand: the ranks of its cards
+// with wild cards replaced by the cards they were used as, the sizes of
+// its groups of cards of the same rank in formed order, and whether it
+// is a straight or a flush.
+type handShape struct {
+	ranks    []Rank
+	groups   []int
+	straight bool
+	flush    bool
+	low      bool
+	badugi   bool
+	desc     Description
+}
+
+func (h *Hand) shape() handShape {
+	s := handShape{desc: h.desc}
+	cards := h.formedCards()
+	for i, card := range cards {
+		s.ranks = append(s.ranks, card.Rank())
+		if i > 0 && s.ranks[i-1] == card.Rank() {
+			s.groups[len(s.groups)-1]++
+		} else {
+			s.groups = append(s.groups, 1)
+		}
+	}
+	c := h.config
+	if c == nil {
+		c = &Config{}
+	}
+	s.low = c.sorting == SortingLow
+	s.badugi = c.badugi
+	if !s.badugi {
+		s.straight = !c.ignoreStraights && HasStraight(cards, *c)
+		s.flush = !c.ignoreFlushes && len(cards) == 5 && HasFlush(cards)
+	}
+	return s
+}
+
+// unpaired returns true if every card has a different rank.
+func (s handShape) unpaired() bool {
+	return len(s.groups) == len(s.ranks)
+}
+
+// made returns the number of cards that make the hand's ranking rather
+// than act as kickers.
+func (s handShape) made() int {
+	if s.straight {
+		return len(s.ranks)
+	}
+	if s.badugi || s.unpaired() {
+		return 1
+	}
+	n := 0
+	for _, g := range s.groups {
+		if g > 1 {
+			n += g
+		}
+	}
+	return n
+}
+
+// name returns the name of the shape in the language of the locale such
+// as "two pair" or "ace high".
+func (s handShape) name(l *Locale) string {
+	if s.badugi {
+		d := s.desc.Render(l)
+		return d[strings.Index(d, " ")+1:]
+	}
+	if s.unpaired() {
+		high := []string{"{1}", l.RankName(s.ranks[0])}
+		switch {
+		case s.straight:
+			return l.phrase("straight")
+		case s.flush:
+			return l.phrase("{1}-high flush", high...)
+		case s.low:
+			return l.phrase("{1} low", high...)
+		}
+		return l.phrase("{1} high", high...)
+	}
+	switch {
+	case s.groups[0] == 5:
+		return l.phrase("five of a kind")
+	case s.groups[0] == 4:
+		return l.phrase("four of a kind")
+	case s.groups[0] == 3 && len(s.groups) > 1 && s.groups[1] == 2:
+		return l.phrase("full house")
+	case s.groups[0] == 3:
+		return l.phrase("three of a kind")
+	case len(s.groups) > 1 && s.groups[1] == 2:
+		return l.phrase("two pair")
+	}
+	return l.phrase("pair")
+}
+
+// part returns the English name of the part of the hand that the card at
+// the position belongs to, such as "top pair" or "straight", which is
+// translated by the phrases of locales.
+func (s handShape) part(position int) string {
+	if s.badugi || s.unpaired() {
+		switch {
+		case s.straight:
+			return "straight"
+		case s.flush:
+			return "flush"
+		}
+		return "card"
+	}
+	i, n := 0, 0
+	for n+s.groups[i] <= position {
+		n += s.groups[i]
+		i++
+	}
+	switch s.groups[i] {
+	case 5:
+		return "five of a kind"
+	case 4:
+		return "four of a kind"
+	case 3:
+		return "three of a kind"
+	}
+	if len(s.groups) > 1 && s.groups[0] == 2 && s.groups[1] == 2 {
+		if i == 0 {
+			return "top pair"
+		}
+		return "second pair"
+	}
+	return "pair"
+}
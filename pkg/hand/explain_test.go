@@ -0,0 +1,102 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestFullDescription(t *testing.T) {
+	tests := []struct {
+		cards       []hand.Card
+		options     []func(*hand.Config)
+		description string
+	}{
+		{Cards("Ks", "Qh", "Qs", "Js", "9d"), nil, "pair of queens, king-jack-nine kickers"},
+		{Cards("Ks", "Qs", "Js", "As", "9d"), nil, "high card ace high, king-queen-jack-nine kickers"},
+		{Cards("2s", "Qh", "Qs", "Js", "2d"), nil, "two pair queens and twos, jack kicker"},
+		{Cards("6s", "Qh", "Ks", "6h", "6d"), nil, "three of a kind sixes, king-queen kickers"},
+		{Cards("Ks", "Qs", "Js", "As", "Td"), nil, "straight ace high"},
+		{Cards("7s", "4s", "5s", "3s", "2s"), nil, "flush seven high, five-four-three-two"},
+		{Cards("6s", "6h", "Ks", "Kh", "6d"), nil, "full house sixes full of kings"},
+		{Cards("As", "Ah", "Ad", "Ac", "5d"), nil, "four of a kind aces, five kicker"},
+		{Cards("Ah", "Kh", "Qh", "Jh", "Th"), nil, "royal flush"},
+		{Cards("7s", "5h", "4d", "3c", "2s"), []func(*hand.Config){hand.DeuceToSevenLow}, "seven-five-four-three-two low"},
+		{Cards("8s", "6h", "5d", "4c", "As"), []func(*hand.Config){hand.AceToFiveLow}, "high card eight high, six-five-four-ace kickers"},
+		{append(Cards("Kh", "Kd", "7c", "3s"), hand.BlackJoker), []func(*hand.Config){hand.JokerBug}, "pair of kings, ace-seven-three kickers (🃏 as ace)"},
+		{Cards("Qs", "Qh"), nil, "pair of queens"},
+	}
+	for _, test := range tests {
+		h := hand.New(test.cards, test.options...)
+		if d := h.FullDescription(); d != test.description {
+			t.Fatalf("expected %q got %q", test.description, d)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		a, b    []hand.Card
+		options []func(*hand.Config)
+		winner  int
+		reason  hand.Reason
+		kicker  int
+		text    string
+	}{
+		{
+			Cards("Qs", "Qh", "Ks", "Jd", "9c"), Cards("Qd", "Qc", "Kh", "Tc", "9s"), nil,
+			0, hand.ReasonKicker, 2, "same pair, wins on second kicker J vs T",
+		},
+		{
+			Cards("Js", "Jh", "As", "Kd", "9c"), Cards("Qd", "Qc", "2h", "3c", "4s"), nil,
+			1, hand.ReasonRanks, 0, "higher pair Q vs J",
+		},
+		{
+			Cards("Ks", "Kh", "8s", "8d", "2c"), Cards("Kd", "Kc", "5h", "5c", "As"), nil,
+			0, hand.ReasonRanks, 0, "same top pair, higher second pair 8 vs 5",
+		},
+		{
+			Cards("Ks", "Kh", "Kd", "9s", "9d"), Cards("Kc", "Kh", "Kd", "8h", "8c"), nil,
+			0, hand.ReasonRanks, 0, "same three of a kind, higher pair 9 vs 8",
+		},
+		{
+			Cards("As", "9s", "7s", "4s", "2s"), Cards("Ah", "9h", "6h", "4h", "3h"), nil,
+			0, hand.ReasonKicker, 3, "same ace-high flush, wins on third card 7 vs 6",
+		},
+		{
+			Cards("Ts", "9h", "8d", "7c", "6s"), Cards("As", "Ks", "9s", "5s", "2s"), nil,
+			1, hand.ReasonRanking, 0, "flush ace high beats straight ten high",
+		},
+		{
+			Cards("As", "Kh", "9d", "7c", "3s"), Cards("Ad", "Kc", "9s", "7h", "3d"), nil,
+			-1, hand.ReasonTie, 0, "tie, both have high card ace high, king-nine-seven-three kickers",
+		},
+		{
+			Cards("8s", "6h", "5d", "4c", "As"), Cards("8d", "7h", "3d", "2c", "Ah"), []func(*hand.Config){hand.AceToFiveLow},
+			0, hand.ReasonKicker, 2, "same eight low, wins on second card 6 vs 7",
+		},
+		{
+			Cards("7s", "5h", "4d", "3c", "2s"), Cards("8d", "5c", "4s", "3h", "2d"), []func(*hand.Config){hand.DeuceToSevenLow},
+			0, hand.ReasonRanks, 0, "lower card 7 vs 8",
+		},
+	}
+	for _, test := range tests {
+		a := hand.New(test.a, test.options...)
+		b := hand.New(test.b, test.options...)
+		e := hand.Explain(a, b)
+		switch test.winner {
+		case 0:
+			if e.Winner != a || e.Loser != b {
+				t.Fatalf("expected %v to beat %v", a, b)
+			}
+		case 1:
+			if e.Winner != b || e.Loser != a {
+				t.Fatalf("expected %v to beat %v", b, a)
+			}
+		}
+		if e.Reason != test.reason || e.Kicker != test.kicker || e.String() != test.text {
+			t.Fatalf("expected %v %d %q got %v %d %q", test.reason, test.kicker, test.text, e.Reason, e.Kicker, e.String())
+		}
+	}
+}
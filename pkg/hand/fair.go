@@ -0,0 +1,246 @@
+package hand
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sync"
+)
+
+// seedSize is the number of random bytes in a server seed.
+const seedSize = 32
+
+var (
+	// ErrNoFairRound is returned by Reveal if no deck has been dealt.
+	ErrNoFairRound = errors.New("hand: no deck has been dealt")
+
+	// ErrCommitmentMismatch is returned by VerifyDeck if the server seed,
+	// nonce, game type, and cards don't hash to the commitment published
+	// before the deal.
+	ErrCommitmentMismatch = errors.New("hand: server seed doesn't match commitment")
+
+	// ErrInvalidSeed is returned by VerifyDeck if the server seed isn't
+	// hex encoded.
+	ErrInvalidSeed = errors.New("hand: server seed must be hex encoded")
+
+	// ErrNoFairCards is returned by VerifyDeck if the round has no cards
+	// to shuffle.
+	ErrNoFairCards = errors.New("hand: round has no cards to shuffle")
+)
+
+// A FairRound holds everything needed to rebuild and check a deck dealt
+// by a FairDealer.  Commitment is the hex encoded SHA-256 hash of the
+// hex encoded ServerSeed, the Nonce, the GameType, and the unshuffled
+// Cards, which is published before the deal, and the server seed is only
+// revealed after the hand.  Cards holds the unshuffled cards of the game
+// type, so the deck can be rebuilt without the scheme registered for the
+// GameType.  Everything but the client seeds is committed to, so none of
+// it can be chosen after the client seeds are known.
+type FairRound struct {
+	Commitment  string   `json:"commitment"`
+	ServerSeed  string   `json:"serverSeed"`
+	ClientSeeds []string `json:"clientSeeds"`
+	Nonce       uint64   `json:"nonce"`
+	GameType    GameType `json:"gameType"`
+	Cards       []Card   `json:"cards"`
+}
+
+// FairDealer is a provably fair Dealer.  Each deck is shuffled with a
+// stream of bytes derived with HMAC-SHA256 from a server seed drawn from
+// crypto/rand, the client seeds added by the players, and a nonce that
+// counts the decks dealt.  The commitment to the next deck can be
+// published before the hand and the seed revealed after it so that
+// players can rebuild the deck with VerifyDeck.  FairDealer is safe for
+// concurrent use.
+type FairDealer struct {
+	mu          sync.Mutex
+	gameType    GameType
+	serverSeed  []byte
+	clientSeeds []string
+	nonce       uint64
+	last        *FairRound
+}
+
+// NewFairDealer returns a FairDealer for the game type with a new server
+// seed.  An error is returned if crypto/rand fails.
+func NewFairDealer(gameType GameType) (*FairDealer, error) {
+	seed, err := newServerSeed()
+	if err != nil {
+		return nil, err
+	}
+	return &FairDealer{gameType: gameType, serverSeed: seed}, nil
+}
+
+func newServerSeed() ([]byte, error) {
+	b := make([]byte, seedSize)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Commitment returns the commitment to the server seed, nonce, game type,
+// and cards of the next deck.
+func (d *FairDealer) Commitment() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return commit(&FairRound{
+		ServerSeed: hex.EncodeToString(d.serverSeed),
+		Nonce:      d.nonce,
+		GameType:   d.gameType,
+		Cards:      Scheme(d.gameType).Cards(),
+	})
+}
+
+// AddClientSeed adds a player's seed to the next deck.  Client seeds are
+// cleared after every deck.
+func (d *FairDealer) AddClientSeed(seed string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.clientSeeds = append(d.clientSeeds, seed)
+}
+
+// Deck implements the Dealer interface.  The server seed used is
+// replaced by a new one, so Commitment returns the commitment of the
+// next deck and Reveal the seeds of this one.  Deck panics if crypto/rand
+// fails.
+func (d *FairDealer) Deck() *Deck {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	next, err := newServerSeed()
+	if err != nil {
+		panic("hand: can't read random server seed: " + err.Error())
+	}
+	round := &FairRound{
+		ServerSeed:  hex.EncodeToString(d.serverSeed),
+		ClientSeeds: d.clientSeeds,
+		Nonce:       d.nonce,
+		GameType:    d.gameType,
+		Cards:       Scheme(d.gameType).Cards(),
+	}
+	round.Commitment = commit(round)
+	// the seed is never empty and the scheme always has cards, so the
+	// deck can be shuffled without the checks of fairDeck
+	deck := shuffleFair(d.serverSeed, round)
+	d.last = round
+	d.serverSeed = next
+	d.clientSeeds = nil
+	d.nonce++
+	return deck
+}
+
+// Reveal returns the seeds of the last deck dealt.  ErrNoFairRound is
+// returned if no deck has been dealt.
+func (d *FairDealer) Reveal() (FairRound, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.last == nil {
+		return FairRound{}, ErrNoFairRound
+	}
+	r := *d.last
+	r.ClientSeeds = append([]string{}, r.ClientSeeds...)
+	r.Cards = append([]Card{}, r.Cards...)
+	return r, nil
+}
+
+// VerifyDeck checks that the server seed, nonce, game type, and cards of
+// the round match its commitment and rebuilds the deck in the order it was
+// dealt.
+func VerifyDeck(r FairRound) (*Deck, error) {
+	if len(r.Cards) == 0 {
+		return nil, ErrNoFairCards
+	}
+	if commit(&r) != r.Commitment {
+		return nil, ErrCommitmentMismatch
+	}
+	return fairDeck(&r)
+}
+
+// commit returns the hex encoded SHA-256 hash of the round's server
+// seed, followed by its nonce and game type as big endian integers and
+// each of its cards prefixed by a comma.
+func commit(r *FairRound) string {
+	h := sha256.New()
+	h.Write([]byte(r.ServerSeed))
+	var n [16]byte
+	binary.BigEndian.PutUint64(n[:8], r.Nonce)
+	binary.BigEndian.PutUint64(n[8:], uint64(r.GameType))
+	h.Write(n[:])
+	for _, c := range r.Cards {
+		h.Write([]byte("," + c.String()))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fairDeck checks the round's server seed and shuffles its cards with
+// shuffleFair.  The round must have cards.
+func fairDeck(r *FairRound) (*Deck, error) {
+	key, err := hex.DecodeString(r.ServerSeed)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSeed
+	}
+	return shuffleFair(key, r), nil
+}
+
+// shuffleFair shuffles a copy of the round's cards with a Fisher-Yates
+// shuffle driven by the byte stream of the key and the round.
+func shuffleFair(key []byte, r *FairRound) *Deck {
+	s := newFairStream(key, r.ClientSeeds, r.Nonce)
+	cards := append([]Card{}, r.Cards...)
+	for i := len(cards) - 1; i > 0; i-- {
+		j := s.intn(uint32(i + 1))
+		cards[i], cards[j] = cards[j], cards[i]
+	}
+	return &Deck{Cards: cards}
+}
+
+// fairStream is a deterministic stream of bytes made of the blocks
+// HMAC-SHA256(server seed, message || counter) for counter 0, 1, 2...
+// The message is the nonce followed by each client seed prefixed by its
+// length so that different seeds never produce the same message.
+type fairStream struct {
+	key     []byte
+	msg     []byte
+	counter uint32
+	block   []byte
+}
+
+func newFairStream(key []byte, clientSeeds []string, nonce uint64) *fairStream {
+	msg := make([]byte, 8, 8+len(clientSeeds)*8)
+	binary.BigEndian.PutUint64(msg, nonce)
+	for _, seed := range clientSeeds {
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], uint32(len(seed)))
+		msg = append(append(msg, n[:]...), seed...)
+	}
+	return &fairStream{key: key, msg: msg}
+}
+
+func (s *fairStream) uint32() uint32 {
+	if len(s.block) < 4 {
+		h := hmac.New(sha256.New, s.key)
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], s.counter)
+		h.Write(s.msg)
+		h.Write(n[:])
+		s.block = h.Sum(nil)
+		s.counter++
+	}
+	v := binary.BigEndian.Uint32(s.block)
+	s.block = s.block[4:]
+	return v
+}
+
+// intn returns an unbiased integer in [0, n) by rejecting the values at
+// the top of the range that would favor the smallest results.
+func (s *fairStream) intn(n uint32) int {
+	limit := ^uint32(0) - ^uint32(0)%n
+	for {
+		if v := s.uint32(); v < limit {
+			return int(v % n)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package hand_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func TestFairDealer(t *testing.T) {
+	d, err := hand.NewFairDealer(hand.GameTypeStandard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Reveal(); err != hand.ErrNoFairRound {
+		t.Fatalf("expected %v got %v", hand.ErrNoFairRound, err)
+	}
+	commitment := d.Commitment()
+	d.AddClientSeed("alice")
+	d.AddClientSeed("bob")
+	deck := d.Deck()
+	if len(deck.Cards) != 52 || hand.HasDuplicates(deck.Cards...) {
+		t.Fatalf("expected a full deck got %v", deck)
+	}
+	if d.Commitment() == commitment {
+		t.Fatal("expected a new commitment for the next deck")
+	}
+	round, err := d.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.Commitment != commitment || round.Nonce != 0 || len(round.ClientSeeds) != 2 {
+		t.Fatalf("expected the published commitment and both client seeds got %+v", round)
+	}
+	verified, err := hand.VerifyDeck(round)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified.String() != deck.String() {
+		t.Fatalf("expected %v got %v", deck, verified)
+	}
+
+	// any change to the seeds changes the deck
+	changed := round
+	changed.ClientSeeds = []string{"alice", "bobby"}
+	if other, err := hand.VerifyDeck(changed); err != nil || other.String() == deck.String() {
+		t.Fatalf("expected a different deck for different client seeds got %v", err)
+	}
+	changed = round
+	changed.ClientSeeds = []string{"alicebob"}
+	if other, err := hand.VerifyDeck(changed); err != nil || other.String() == deck.String() {
+		t.Fatalf("expected a different deck for joined client seeds got %v", err)
+	}
+	changed = round
+	// flip the bits of the first byte so the seed always changes
+	seed, _ := hex.DecodeString(round.ServerSeed)
+	seed[0] ^= 0xff
+	changed.ServerSeed = hex.EncodeToString(seed)
+	if _, err := hand.VerifyDeck(changed); err != hand.ErrCommitmentMismatch {
+		t.Fatalf("expected %v got %v", hand.ErrCommitmentMismatch, err)
+	}
+	changed = round
+	changed.Cards = nil
+	if _, err := hand.VerifyDeck(changed); err != hand.ErrNoFairCards {
+		t.Fatalf("expected %v got %v", hand.ErrNoFairCards, err)
+	}
+	// the nonce is committed to so the server can't pick another deck
+	changed = round
+	changed.Nonce++
+	if _, err := hand.VerifyDeck(changed); err != hand.ErrCommitmentMismatch {
+		t.Fatalf("expected %v got %v", hand.ErrCommitmentMismatch, err)
+	}
+	changed = round
+	changed.GameType = hand.GameTypeShortDeck
+	if _, err := hand.VerifyDeck(changed); err != hand.ErrCommitmentMismatch {
+		t.Fatalf("expected %v got %v", hand.ErrCommitmentMismatch, err)
+	}
+	// the cards are committed to so the server can't reorder them
+	changed = round
+	changed.Cards = append([]hand.Card{}, round.Cards...)
+	changed.Cards[0], changed.Cards[1] = changed.Cards[1], changed.Cards[0]
+	if _, err := hand.VerifyDeck(changed); err != hand.ErrCommitmentMismatch {
+		t.Fatalf("expected %v got %v", hand.ErrCommitmentMismatch, err)
+	}
+
+	// the round carries its cards so it verifies from its JSON alone
+	b, err := json.Marshal(round)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded hand.FairRound
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if verified, err := hand.VerifyDeck(decoded); err != nil || verified.String() != deck.String() {
+		t.Fatalf("expected %v got %v %v", deck, verified, err)
+	}
+
+	next := d.Deck()
+	round, _ = d.Reveal()
+	if round.Nonce != 1 || len(round.ClientSeeds) != 0 || next.String() == deck.String() {
+		t.Fatalf("expected the next deck to have a new nonce and no client seeds got %+v", round)
+	}
+}
+
+func TestFairDealerShortDeck(t *testing.T) {
+	d, err := hand.NewFairDealer(hand.GameTypeShortDeck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck := d.Deck()
+	round, _ := d.Reveal()
+	verified, err := hand.VerifyDeck(round)
+	if err != nil || len(deck.Cards) != 36 || verified.String() != deck.String() {
+		t.Fatalf("expected a verified short deck got %v %v", deck, err)
+	}
+}
@@ -0,0 +1,225 @@
+package hand
+
+import (
+	"errors"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/notnil/joker/util"
+)
+
+// maxFrequencySize is the largest hand size CountFrequencies enumerates.
+// Hands of size cards are counted from the values of every hand of one
+// card less, and there are already 20,358,520 hands of six cards in a
+// standard deck.
+const maxFrequencySize = 7
+
+var (
+	// ErrFrequencySize is returned by CountFrequencies if the hand size is
+	// less than five or more than seven.
+	ErrFrequencySize = errors.New("hand: frequencies require hands of five to seven cards")
+
+	// ErrFrequencyBadugi is returned by CountFrequencies for badugi hands,
+	// which aren't evaluated with lookup tables.
+	ErrFrequencyBadugi = errors.New("hand: frequencies of badugi hands aren't supported")
+
+	// ErrFrequencyWild is returned by CountFrequencies if the options make
+	// cards wild, since only hands without wild cards are enumerated.
+	ErrFrequencyWild = errors.New("hand: frequencies of hands with wild cards aren't supported")
+)
+
+// A RankingFrequency is the number of hands of a ranking and the number
+// of distinct values, or equivalence classes, they have.  Kind names the
+// ranking in any scheme and Best is the description of the best class of
+// the ranking; both are only set if the ranking has hands.
+type RankingFrequency struct {
+	Ranking Ranking
+	Kind    DescriptionKind
+	Hands   int
+	Classes int
+	Best    string
+}
+
+// Probability returns the share of all hands that have the ranking.
+func (r RankingFrequency) Probability(f *Frequencies) float64 {
+	return float64(r.Hands) / float64(f.Hands)
+}
+
+// Frequencies breaks every hand of a size dealt from the deck of a game
+// type down by ranking.  Rankings holds every ranking of the game type's
+// scheme from weakest to strongest, including the rankings that have no
+// hands such as five of a kind.
+type Frequencies struct {
+	GameType GameType
+	Size     int
+	Hands    int
+	Classes  int
+	Rankings []RankingFrequency
+}
+
+// CountFrequencies evaluates every hand of five to seven cards that can
+// be dealt from the deck of the game type set by the options, without
+// jokers, and counts the hands and classes of each ranking.  Hands are
+// evaluated as New would, so the standard deck has 2,598,960 five card
+// hands in 7,462 classes and 133,784,560 seven card hands in 4,824
+// classes.  Hands are enumerated in parallel.  Options that make cards
+// wild return ErrFrequencyWild.
+func CountFrequencies(size int, options ...func(*Config)) (*Frequencies, error) {
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	if c.badugi {
+		return nil, ErrFrequencyBadugi
+	}
+	if c.hasWilds() {
+		return nil, ErrFrequencyWild
+	}
+	cards := []Card{}
+	for _, card := range Scheme(c.gameType).Cards() {
+		if !card.IsJoker() {
+			cards = append(cards, card)
+		}
+	}
+	if size < 5 || size > maxFrequencySize || size > len(cards) {
+		return nil, ErrFrequencySize
+	}
+	t := tableFor(c)
+	counts := countValues(t, cards, size, c.sorting == SortingLow)
+
+	f := &Frequencies{GameType: c.gameType, Size: size}
+	for _, rule := range Scheme(c.gameType).Rankings() {
+		f.Rankings = append(f.Rankings, RankingFrequency{Ranking: rule.Ranking})
+	}
+	sort.Slice(f.Rankings, func(i, j int) bool {
+		return f.Rankings[i].Ranking < f.Rankings[j].Ranking
+	})
+	index := map[Ranking]int{}
+	for i, r := range f.Rankings {
+		index[r.Ranking] = i
+	}
+	for v, n := range counts {
+		if n == 0 {
+			continue
+		}
+		class := t.classes[v]
+		r := &f.Rankings[index[class.ranking]]
+		r.Hands += n
+		r.Classes++
+		if r.Best == "" || c.sorting != SortingLow {
+			r.Kind = class.desc.Kind
+			r.Best = class.description
+		}
+		f.Hands += n
+		f.Classes++
+	}
+	return f, nil
+}
+
+// countValues returns the number of hands of size cards with each value
+// of the table, indexed by value.  The value of every five card hand is
+// looked up and stored by its colexicographic rank, then the value of
+// every larger hand is the best value of the hands made by dropping one
+// of its cards, so each hand is only compared to size others instead of
+// evaluating all of its five card combinations.  Every size is split
+// into a chunk of hands for each processor.
+func countValues(t *evalTable, cards []Card, size int, low bool) []int {
+	n := len(cards)
+	choose := make([][]int, n+1)
+	for v := range choose {
+		choose[v] = make([]int, size+1)
+		for k := range choose[v] {
+			choose[v][k] = util.Binomial(v, k)
+		}
+	}
+	values := make([]uint16, choose[n][5])
+	forEachChunk(n, 5, func(_ int, it *util.CombinationIterator) {
+		for it.Next() {
+			idx := it.Combination()
+			values[it.Rank()] = t.lookup(cards[idx[0]], cards[idx[1]], cards[idx[2]], cards[idx[3]], cards[idx[4]])
+		}
+	})
+	for k := 6; k < size; k++ {
+		prev := values
+		values = make([]uint16, choose[n][k])
+		forEachChunk(n, k, func(_ int, it *util.CombinationIterator) {
+			for it.Next() {
+				values[it.Rank()] = bestSubset(prev, it.Combination(), choose, low)
+			}
+		})
+	}
+	results := make([][]int, runtime.GOMAXPROCS(0))
+	forEachChunk(n, size, func(i int, it *util.CombinationIterator) {
+		counts := make([]int, len(t.classes))
+		for it.Next() {
+			if size == 5 {
+				counts[values[it.Rank()]]++
+			} else {
+				counts[bestSubset(values, it.Combination(), choose, low)]++
+			}
+		}
+		results[i] = counts
+	})
+	counts := make([]int, len(t.classes))
+	for _, result := range results {
+		for v, n := range result {
+			counts[v] += n
+		}
+	}
+	return counts
+}
+
+// bestSubset returns the highest (or lowest if low is true) value of the
+// combinations made by dropping one index of idx, which are looked up in
+// values by colexicographic rank.  Dropping index i shifts the indexes
+// after it down a position, so the rank of each subset is found from the
+// previous one by swapping a single term.
+func bestSubset(values []uint16, idx []int, choose [][]int, low bool) uint16 {
+	rank := 0
+	for j := 1; j < len(idx); j++ {
+		rank += choose[idx[j]][j]
+	}
+	best := values[rank]
+	for i := 0; i < len(idx)-1; i++ {
+		rank += choose[idx[i]][i+1] - choose[idx[i+1]][i+1]
+		if v := values[rank]; low && v < best || !low && v > best {
+			best = v
+		}
+	}
+	return best
+}
+
+// forEachChunk splits the combinations of k out of n into a chunk for
+// each processor and calls f with the index and iterator of each chunk in
+// its own goroutine.  forEachChunk returns once every call has returned.
+func forEachChunk(n, k int, f func(i int, it *util.CombinationIterator)) {
+	var wg sync.WaitGroup
+	for i, chunk := range util.SplitCombinations(n, k, runtime.GOMAXPROCS(0)) {
+		wg.Add(1)
+		go func(i int, chunk util.CombinationChunk) {
+			defer wg.Done()
+			f(i, chunk.Iterator())
+		}(i, chunk)
+	}
+	wg.Wait()
+}
+
+// Inversions returns the rankings that beat a ranking with as many hands
+// or fewer, skipping the rankings without any hands.  Five card hands of
+// the standard deck have no inversions.  Short deck ranks a flush above a
+// full house because flushes are rarer with fewer ranks, so a flush isn't
+// an inversion, although a pair is more common than high card.  Seven
+// card hands have inversions in both decks.
+func (f *Frequencies) Inversions() []Ranking {
+	inversions := []Ranking{}
+	for i, r := range f.Rankings {
+		for _, weaker := range f.Rankings[:i] {
+			if r.Hands > 0 && weaker.Hands > 0 && weaker.Hands <= r.Hands {
+				inversions = append(inversions, r.Ranking)
+				break
+			}
+		}
+	}
+	return inversions
+}
@@ -0,0 +1,73 @@
+package hand_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+)
+
+func TestCountFrequencies(t *testing.T) {
+	tests := []struct {
+		size       int
+		options    []func(*hand.Config)
+		hands      []int
+		classes    int
+		inversions []hand.Ranking
+	}{
+		{5, nil, []int{1302540, 1098240, 123552, 54912, 10200, 5108, 3744, 624, 36, 4, 0}, 7462, []hand.Ranking{}},
+		{7, nil, []int{23294460, 58627800, 31433400, 6461620, 6180020, 4047644, 3473184, 224848, 37260, 4324, 0}, 4824,
+			[]hand.Ranking{hand.StdPair, hand.StdTwoPair}},
+		{5, []func(*hand.Config){hand.ShortDeck}, []int{122400, 193536, 36288, 16128, 6120, 1728, 480, 288, 20, 4, 0}, 1404,
+			[]hand.Ranking{hand.SDPair}},
+		{5, []func(*hand.Config){hand.DeuceToSevenLow}, nil, 7462, nil},
+	}
+	for _, test := range tests {
+		if test.size == 7 && testing.Short() {
+			continue
+		}
+		f, err := hand.CountFrequencies(test.size, test.options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		total := 0
+		for i, r := range f.Rankings {
+			total += r.Hands
+			if test.hands != nil && r.Hands != test.hands[i] {
+				t.Fatalf("expected %d %d card hands of ranking %d got %d", test.hands[i], test.size, r.Ranking, r.Hands)
+			}
+		}
+		if f.Classes != test.classes || total != f.Hands {
+			t.Fatalf("expected %d classes of %d hands got %d of %d", test.classes, total, f.Classes, f.Hands)
+		}
+		if test.inversions != nil && !reflect.DeepEqual(f.Inversions(), test.inversions) {
+			t.Fatalf("expected inversions %v got %v", test.inversions, f.Inversions())
+		}
+	}
+	f, err := hand.CountFrequencies(5, hand.ShortDeck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range f.Rankings {
+		if r.Ranking == hand.SDFlush && r.Kind != hand.DescFlush || r.Ranking == hand.SDFullHouse && r.Kind != hand.DescFullHouse {
+			t.Fatalf("expected short deck rankings to be named by their kind got %v for %d", r.Kind, r.Ranking)
+		}
+	}
+
+	errorTests := []struct {
+		size    int
+		options []func(*hand.Config)
+		err     error
+	}{
+		{4, nil, hand.ErrFrequencySize},
+		{8, nil, hand.ErrFrequencySize},
+		{5, []func(*hand.Config){hand.Badugi}, hand.ErrFrequencyBadugi},
+		{5, []func(*hand.Config){hand.Wild(hand.Two)}, hand.ErrFrequencyWild},
+		{5, []func(*hand.Config){hand.JokersWild}, hand.ErrFrequencyWild},
+	}
+	for _, test := range errorTests {
+		if _, err := hand.CountFrequencies(test.size, test.options...); err != test.err {
+			t.Fatalf("expected %v got %v", test.err, err)
+		}
+	}
+}
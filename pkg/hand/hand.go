@@ -8,6 +8,8 @@ import (
 	"github.com/notnil/joker/util"
 )
 
+//go:generate stringer -type=Sorting,Ordering -output=stringer_autogen.go hand.go
+
 // Sorting is the sorting used to determine which hand is
 // selected.
 type Sorting int
@@ -37,14 +39,27 @@ type Config struct {
 	ignoreStraights bool
 	ignoreFlushes   bool
 	aceIsLow        bool
+	deuceToSeven    bool
+	badugi          bool
+	wilds           uint16
+	jokersWild      bool
+	jokerBug        bool
+	multiDeck       bool
 	gameType        GameType
 }
 
 type configJSON struct {
-	Sorting         Sorting `json:"sorting"`
-	IgnoreStraights bool    `json:"ignoreStraights"`
-	IgnoreFlushes   bool    `json:"ignoreFlushes"`
-	AceIsLow        bool    `json:"aceIsLow"`
+	Sorting         Sorting  `json:"sorting"`
+	IgnoreStraights bool     `json:"ignoreStraights"`
+	IgnoreFlushes   bool     `json:"ignoreFlushes"`
+	AceIsLow        bool     `json:"aceIsLow"`
+	DeuceToSeven    bool     `json:"deuceToSeven,omitempty"`
+	Badugi          bool     `json:"badugi,omitempty"`
+	Wilds           []Rank   `json:"wilds,omitempty"`
+	JokersWild      bool     `json:"jokersWild,omitempty"`
+	JokerBug        bool     `json:"jokerBug,omitempty"`
+	MultiDeck       bool     `json:"multiDeck,omitempty"`
+	GameType        GameType `json:"gameType,omitempty"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -54,6 +69,13 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 		IgnoreStraights: c.ignoreStraights,
 		IgnoreFlushes:   c.ignoreFlushes,
 		AceIsLow:        c.aceIsLow,
+		DeuceToSeven:    c.deuceToSeven,
+		Badugi:          c.badugi,
+		Wilds:           c.wildRanks(),
+		JokersWild:      c.jokersWild,
+		JokerBug:        c.jokerBug,
+		MultiDeck:       c.multiDeck,
+		GameType:        c.gameType,
 	}
 	return json.Marshal(m)
 }
@@ -68,6 +90,13 @@ func (c *Config) UnmarshalJSON(b []byte) error {
 	c.ignoreStraights = m.IgnoreStraights
 	c.ignoreFlushes = m.IgnoreFlushes
 	c.aceIsLow = m.AceIsLow
+	c.deuceToSeven = m.DeuceToSeven
+	c.badugi = m.Badugi
+	Wild(m.Wilds...)(c)
+	c.jokersWild = m.JokersWild
+	c.jokerBug = m.JokerBug
+	c.multiDeck = m.MultiDeck
+	c.gameType = m.GameType
 	return nil
 }
 
@@ -86,6 +115,26 @@ func AceToFiveLow(c *Config) {
 	c.ignoreFlushes = true
 }
 
+// DeuceToSevenLow configures NewHand to select the lowest hand in which
+// aces are always high, straights and flushes are counted, and ace, two,
+// three, four, five isn't a straight.  Hands without a pair, straight, or
+// flush are described by their two highest cards such as "seven-five low".
+func DeuceToSevenLow(c *Config) {
+	c.sorting = SortingLow
+	c.deuceToSeven = true
+}
+
+// MultiDeck configures NewHand to rank hands that may hold more than one
+// copy of a card, as dealt from a shoe of several decks.  Flushes may
+// then have paired ranks and five of a kind is possible without wild
+// cards.  Hands with duplicate cards are always ranked this way, but
+// hands dealt from a shoe should be formed with it so that hands with
+// and without duplicates are ranked with the same rankings.
+func MultiDeck(c *Config) {
+	c.multiDeck = true
+}
+
+// ShortDeck configures NewHand to rank hands by short deck rules.
 func ShortDeck(c *Config) {
 	c.gameType = GameTypeShortDeck
 }
@@ -95,28 +144,126 @@ type Hand struct {
 	ranking     Ranking
 	cards       []Card
 	description string
+	desc        Description
 	config      *Config
+	value       int
+	wilds       []WildCard
 }
 
 // New forms a hand from the given cards and configuration
 // options.  If there are more than five cards, New will return
 // the winning hand out of all five card combinations.  If there are
 // less than five cards, the best ranking will be calculated for the
-// cards given.
+// cards given.  Hands of five or more cards are evaluated with lookup
+// tables that are built for each configuration on first use.  If the
+// Badugi option is given, New returns the best badugi hand instead.
+// Wild cards are used as the card that makes the best hand.
 func New(cards []Card, options ...func(*Config)) *Hand {
 	c := &Config{}
 	for _, option := range options {
 		option(c)
 	}
-	combos := cardCombos(cards)
-	hands := []*Hand{}
-	for _, combo := range combos {
-		hand := handForFiveCards(combo, *c)
-		hands = append(hands, hand)
+	if c.badugi {
+		return newBadugi(cards, c)
+	}
+	if hasJoker(cards) && !c.hasWilds() {
+		c.jokersWild = true
+	}
+	if HasDuplicates(cards...) {
+		c.multiDeck = true
+	}
+	if len(cards) < 5 {
+		if hasWild(cards, c) {
+			return newShortWild(cards, c)
+		}
+		hand := handForFiveCards(append([]Card{}, cards...), *c)
+		hand.config = c
+		return hand
+	}
+	t := tableFor(c)
+	if c.hasWilds() {
+		return t.bestWild(c, func(f func(c0, c1, c2, c3, c4 Card)) {
+			forEachCombo(cards, f)
+		})
+	}
+	v, best := t.best(cards, c.sorting == SortingLow)
+	return t.hand(best, v, c)
+}
+
+// NewOmaha forms an Omaha hand from the hole and board cards and
+// configuration options.  The hand is the winning hand out of all
+// combinations of exactly two hole cards and three board cards, so any
+// number of hole cards such as the four, five, or six of Omaha variants
+// may be given.  Before the flop, or with less than two hole cards,
+// NewOmaha returns the best hand of less than five cards made of two
+// hole cards, or every hole card if there are fewer, and up to three
+// board cards, such as a pair of aces for A-A-K-Q preflop.  Without any
+// cards NewOmaha returns an empty hand of the lowest ranking of the game
+// type with no cards and no description.
+func NewOmaha(hole, board []Card, options ...func(*Config)) *Hand {
+	if len(hole) < 2 || len(board) < 3 {
+		return newShortOmaha(hole, board, options)
+	}
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	if (hasJoker(hole) || hasJoker(board)) && !c.hasWilds() {
+		c.jokersWild = true
 	}
-	hands = Sort(c.sorting, DESC, hands...)
-	hands[0].config = c
-	return hands[0]
+	if HasDuplicates(hole...) || HasDuplicates(board...) || NewCardSet(hole...).Overlaps(NewCardSet(board...)) {
+		c.multiDeck = true
+	}
+	t := tableFor(c)
+	if c.hasWilds() {
+		return t.bestWild(c, func(f func(c0, c1, c2, c3, c4 Card)) {
+			forEachOmahaCombo(hole, board, f)
+		})
+	}
+	v, cards := t.bestOmaha(hole, board, c.sorting == SortingLow)
+	return t.hand(cards, v, c)
+}
+
+// newShortOmaha returns the best hand out of every combination of two
+// hole cards and three board cards, or all of them if there are fewer.
+func newShortOmaha(hole, board []Card, options []func(*Config)) *Hand {
+	if len(hole) == 0 && len(board) == 0 {
+		c := &Config{}
+		for _, option := range options {
+			option(c)
+		}
+		return &Hand{ranking: lowestRanking(c.gameType), cards: []Card{}, config: c}
+	}
+	var best *Hand
+	util.ForEachCombination(len(hole), min(2, len(hole)), func(h []int) bool {
+		util.ForEachCombination(len(board), min(3, len(board)), func(b []int) bool {
+			cards := make([]Card, 0, 5)
+			for _, i := range h {
+				cards = append(cards, hole[i])
+			}
+			for _, i := range b {
+				cards = append(cards, board[i])
+			}
+			if hand := New(cards, options...); best == nil || compareHands(hand, best) > 0 {
+				best = hand
+			}
+			return true
+		})
+		return true
+	})
+	return best
+}
+
+// lowestRanking returns the lowest ranking of the game type's scheme.
+func lowestRanking(g GameType) Ranking {
+	rules := Scheme(g).Rankings()
+	lowest := rules[0].Ranking
+	for _, rule := range rules[1:] {
+		if rule.Ranking < lowest {
+			lowest = rule.Ranking
+		}
+	}
+	return lowest
 }
 
 // Ranking returns the hand ranking of the hand.
@@ -130,7 +277,8 @@ func (h *Hand) Cards() []Card {
 }
 
 // Description returns a user displayable description of the hand such as
-// "full house kings full of sixes".
+// "full house kings full of sixes".  The description is rendered in
+// English; LocalDescription renders it in other languages.
 func (h *Hand) Description() string {
 	return h.description
 }
@@ -140,23 +288,82 @@ func (h *Hand) String() string {
 	return fmt.Sprintf("%s %v", h.Description(), h.Cards())
 }
 
+// Value returns the strength of the hand as a single integer.  Of two
+// hands formed with the same options the hand with the greater value wins
+// and hands with equal values tie.  Values are dense and start at one, so
+// standard high hands range from 1 (seven five high) to 7462 (royal
+// flush).  Low hands are numbered in reverse so that the best low has the
+// greatest value.  Hands of less than five cards, and hands with a rank
+// the game type's deck doesn't have such as a two in short deck, aren't
+// part of the order: Value returns zero for them, so they must be
+// compared with CompareTo or Sort, which order them by ranking and then
+// by rank.
+func (h *Hand) Value() int {
+	if h.value == 0 || h.config == nil || h.config.sorting != SortingLow {
+		return h.value
+	}
+	return tableFor(h.config).valueCount() + 1 - h.value
+}
+
+// ValueCount returns the number of distinct values hands formed with the
+// given options can have.
+func ValueCount(options ...func(*Config)) int {
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	return tableFor(c).valueCount()
+}
+
+// RankingForValue returns the ranking and description shared by all hands
+// with the given value when formed with the given options.  An error is
+// returned if the value is out of range.
+func RankingForValue(value int, options ...func(*Config)) (Ranking, string, error) {
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	t := tableFor(c)
+	n := t.valueCount()
+	if value < 1 || value > n {
+		return 0, "", fmt.Errorf("hand: invalid value %d", value)
+	}
+	if c.sorting == SortingLow {
+		value = n + 1 - value
+	}
+	class := t.deckClass(value)
+	return class.ranking, class.description, nil
+}
+
 // CompareTo returns a positive value if this hand beats the other hand, a
 // negative value if this hand loses to the other hand, and zero if the hands
-// are equal.
+// are equal.  The comparison is always made as if both hands are high hands
+// so Sort should be used to order low hands.
 func (h *Hand) CompareTo(o *Hand) int {
+	if h.value != 0 && o.value != 0 && sameTable(h.config, o.config) {
+		return h.value - o.value
+	}
 	if h.Ranking() != o.Ranking() {
 		return int(h.Ranking()) - int(o.Ranking())
 	}
 	hCards := h.Cards()
 	oCards := o.Cards()
-	for i := 0; i < 5; i++ {
+	for i := 0; i < len(hCards) && i < len(oCards); i++ {
 		hCard, oCard := hCards[i], oCards[i]
 		hIndex, oIndex := hCard.Rank(), oCard.Rank()
 		if hIndex != oIndex {
 			return int(hIndex) - int(oIndex)
 		}
 	}
-	return 0
+	// hands of less than five cards with more cards have more kickers
+	return len(hCards) - len(oCards)
+}
+
+func sameTable(a, b *Config) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return newTableKey(a) == newTableKey(b)
 }
 
 type handJSON struct {
@@ -180,7 +387,9 @@ func (h *Hand) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
-//  The json format is:
+//
+//	The json format is:
+//
 // {"ranking":10,"cards":["A♠","K♠","Q♠","J♠","T♠"],"description":"royal flush","config":{"sorting":1,"ignoreStraights":false,"ignoreFlushes":false,"aceIsLow":false}}
 func (h *Hand) UnmarshalJSON(b []byte) error {
 	m := &handJSON{}
@@ -188,16 +397,16 @@ func (h *Hand) UnmarshalJSON(b []byte) error {
 		return err
 	}
 	f := func(c *Config) {
-		c.sorting = m.Config.sorting
-		c.ignoreStraights = m.Config.ignoreStraights
-		c.ignoreFlushes = m.Config.ignoreFlushes
-		c.aceIsLow = m.Config.aceIsLow
+		*c = *m.Config
 	}
 	cp := New(m.Cards, f)
 	h.ranking = cp.ranking
 	h.cards = cp.cards
 	h.description = cp.description
+	h.desc = cp.desc
+	h.wilds = cp.wilds
 	h.config = cp.config
+	h.value = cp.value
 	return nil
 }
 
@@ -231,64 +440,91 @@ func (a byHighHand) Less(i, j int) bool {
 }
 
 func handForFiveCards(cards []Card, c Config) *Hand {
-	cards = formCards(cards, c)
-	rankings := getRankingsByType(c.gameType)
-	for _, r := range rankings {
-		if r.vFunc(cards, c) {
-			return &Hand{
-				ranking:     r.r,
-				cards:       cards,
-				description: r.dFunc(cards),
-			}
-		}
+	hand, ok := classifyCards(cards, c)
+	if !ok {
+		panic("unreachable")
 	}
-	panic("unreachable")
+	return hand
 }
 
-func cardCombos(cards []Card) [][]Card {
-	cCombo := [][]Card{}
-	l := 5
-	if len(cards) < 5 {
-		l = len(cards)
-	}
-	indexCombos := util.Combinations(len(cards), l)
-
-	for _, combo := range indexCombos {
-		cCards := []Card{}
-		for _, i := range combo {
-			cCards = append(cCards, cards[i])
+// classifyCards forms the cards and returns the hand for the first
+// ranking of the scheme they are valid for.  Only duplicate cards make
+// cards valid for more than one of the standard rankings, such as a
+// flush with a pair, so cards with duplicates are given the highest
+// ranking they are valid for instead, and a flush formed that way is
+// arranged by rank like any other.  classifyCards returns false if the
+// cards aren't valid for any ranking.
+func classifyCards(cards []Card, c Config) (*Hand, bool) {
+	cards = formCards(cards, c)
+	duplicates := HasDuplicates(cards...)
+	rules := Scheme(c.gameType).Rankings()
+	var best *RankingRule
+	for i := range rules {
+		if !rules[i].Valid(cards, c) {
+			continue
+		}
+		if best == nil || rules[i].Ranking > best.Ranking {
+			best = &rules[i]
+		}
+		if !duplicates {
+			break
 		}
-		cCombo = append(cCombo, cCards)
 	}
-	return cCombo
+	if best == nil {
+		return nil, false
+	}
+	desc := best.Describe(cards)
+	if desc.Kind == DescFlush {
+		cards = sortByRank(cards, c.aceIsLow)
+		desc = best.Describe(cards)
+	}
+	if c.deuceToSeven && best.Ranking == StdHighCard {
+		desc = lowDescription(cards)
+	}
+	desc.Ranking = best.Ranking
+	return &Hand{
+		ranking:     best.Ranking,
+		cards:       cards,
+		description: desc.String(),
+		desc:        desc,
+	}, true
 }
 
-func formLowStraight(cards []Card) []Card {
-	if len(cards) < 5 {
-		return cards
-	}
-	has := cards[0].Rank() == Ace &&
-		cards[1].Rank() == Five &&
-		cards[2].Rank() == Four &&
-		cards[3].Rank() == Three &&
-		cards[4].Rank() == Two
-	if has {
-		cards = []Card{cards[1], cards[2], cards[3], cards[4], cards[0]}
+// sortByRank returns the cards sorted from the highest rank to the
+// lowest keeping the order of cards of the same rank.
+func sortByRank(cards []Card, aceIsLow bool) []Card {
+	sorted := append([]Card{}, cards...)
+	if aceIsLow {
+		sort.Stable(sort.Reverse(byAceLow(sorted)))
+	} else {
+		sort.Stable(sort.Reverse(byAceHigh(sorted)))
 	}
-	return cards
+	return sorted
 }
 
-func formLowSDStraight(cards []Card) []Card {
-	if len(cards) < 5 {
+// formStraight arranges the cards in the order of the straight whose
+// ranks they have, so a wheel is formed as 5-4-3-2-A.
+func formStraight(cards []Card, straights [][]Rank) []Card {
+	if len(cards) != 5 {
 		return cards
 	}
-	has := cards[0].Rank() == Ace &&
-		cards[1].Rank() == Nine &&
-		cards[2].Rank() == Eight &&
-		cards[3].Rank() == Seven &&
-		cards[4].Rank() == Six
-	if has {
-		cards = []Card{cards[1], cards[2], cards[3], cards[4], cards[0]}
+	var mask uint16
+	for _, c := range cards {
+		mask |= 1 << uint(c.Rank())
+	}
+	for _, straight := range straights {
+		var sMask uint16
+		for _, r := range straight {
+			sMask |= 1 << uint(r)
+		}
+		if sMask != mask || len(straight) != 5 {
+			continue
+		}
+		formed := []Card{}
+		for _, r := range straight {
+			formed = append(formed, cardsForRank(cards, r)...)
+		}
+		return formed
 	}
 	return cards
 }
@@ -311,7 +547,7 @@ func formCards(cards []Card, c Config) []Card {
 
 	// form cards starting w/ most paired
 	formed := []Card{}
-	for i := 4; i > 0; i-- {
+	for i := 5; i > 0; i-- {
 		for _, r := range ranks {
 			rCards := cardsForRank(cards, r)
 			if len(rCards) == i {
@@ -319,15 +555,17 @@ func formCards(cards []Card, c Config) []Card {
 			}
 		}
 	}
-	// check for low straight
-	switch c.gameType {
-	case GameTypeShortDeck:
-		return formLowSDStraight(formed)
-	case GameTypeStandard:
-		fallthrough
-	default:
-		return formLowStraight(formed)
+	// check for straights with the ace low
+	if c.deuceToSeven || c.ignoreStraights {
+		return formed
 	}
+	return formStraight(formed, Scheme(c.gameType).Straights())
+}
+
+// lowDescription returns a description of a low hand by its two highest
+// cards such as "seven-five low".
+func lowDescription(cards []Card) Description {
+	return Description{Kind: DescLow, Ranks: []Rank{cards[0].Rank(), cards[1].Rank()}}
 }
 
 func cardsForRank(cards []Card, r Rank) []Card {
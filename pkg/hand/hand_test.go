@@ -228,6 +228,23 @@ func TestBlanks(t *testing.T) {
 	}
 }
 
+func TestRankingString(t *testing.T) {
+	tests := map[hand.Ranking]string{
+		hand.StdHighCard:    "HighCard",
+		hand.StdFullHouse:   "FullHouse",
+		hand.StdRoyalFlush:  "RoyalFlush",
+		hand.StdFiveOfAKind: "FiveOfAKind",
+		hand.BadugiFourCard: "BadugiFourCard",
+		hand.BadugiOneCard:  "BadugiOneCard",
+		hand.Ranking(42):    "Ranking(42)",
+	}
+	for r, expected := range tests {
+		if r.String() != expected {
+			t.Fatalf("expected %q got %q", expected, r.String())
+		}
+	}
+}
+
 func TestDeck(t *testing.T) {
 	r := rand.New(rand.NewSource(0))
 	deck := hand.NewDealer(r, hand.GameTypeStandard).Deck()
@@ -241,7 +258,7 @@ func TestDeck(t *testing.T) {
 }
 
 func TestHandJSON(t *testing.T) {
-	jsonStr := `{"standard":10,"cards":["A♠","K♠","Q♠","J♠","T♠"],"description":"royal flush","config":{"sorting":1,"ignoreStraights":false,"ignoreFlushes":false,"aceIsLow":false}}`
+	jsonStr := `{"ranking":10,"cards":["A♠","K♠","Q♠","J♠","T♠"],"description":"royal flush","config":{"sorting":1,"ignoreStraights":false,"ignoreFlushes":false,"aceIsLow":false}}`
 	h := &hand.Hand{}
 	if err := json.Unmarshal([]byte(jsonStr), h); err != nil {
 		t.Fatal(err)
@@ -263,3 +280,132 @@ func BenchmarkHandCreation(b *testing.B) {
 		hand.New(cards)
 	}
 }
+
+type testValue struct {
+	cards   []hand.Card
+	options []func(*hand.Config)
+	value   int
+}
+
+var valueTests = []testValue{
+	{Cards("As", "Ks", "Qs", "Js", "Ts"), nil, 7462},
+	{Cards("7s", "5d", "4s", "3s", "2s"), nil, 1},
+	{Cards("7s", "5s", "4s", "3s", "2s"), nil, 5864},
+	{Cards("As", "Ks", "Qs", "Js", "9d"), nil, 1277},
+	{Cards("5d", "4s", "3s", "2s", "As"), []func(*hand.Config){hand.AceToFiveLow}, 6175},
+	{Cards("6d", "4s", "3s", "2s", "As"), []func(*hand.Config){hand.AceToFiveLow}, 6174},
+	{Cards("7s", "5d", "4s", "3s", "2s"), []func(*hand.Config){hand.Low}, 7462},
+	{Cards("As", "Ks", "Qs", "Js", "Ts"), []func(*hand.Config){hand.Low}, 1},
+}
+
+func TestValue(t *testing.T) {
+	for _, test := range valueTests {
+		h := hand.New(test.cards, test.options...)
+		if h.Value() != test.value {
+			t.Fatalf("expected %v to have value %d got %d", h, test.value, h.Value())
+		}
+		ranking, desc, err := hand.RankingForValue(h.Value(), test.options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ranking != h.Ranking() || desc != h.Description() {
+			t.Fatalf("expected value %d to be %v %q got %v %q", h.Value(), h.Ranking(), h.Description(), ranking, desc)
+		}
+	}
+	if _, _, err := hand.RankingForValue(7463); err == nil {
+		t.Fatal("expected error for out of range value")
+	}
+}
+
+func TestValueShortHands(t *testing.T) {
+	pair := hand.New(Cards("As", "Ad"))
+	trips := hand.New(Cards("2s", "2d", "2h"))
+	full := hand.New(Cards("As", "Ad", "Kc", "Qh", "Jd"))
+	for _, h := range []*hand.Hand{pair, trips} {
+		if h.Value() != 0 {
+			t.Fatalf("expected %v to have no value got %d", h, h.Value())
+		}
+	}
+	if trips.CompareTo(pair) <= 0 {
+		t.Fatalf("expected %v to beat %v", trips, pair)
+	}
+	// kickers are missing from the short pair
+	if full.CompareTo(pair) <= 0 || pair.CompareTo(full) >= 0 {
+		t.Fatalf("expected %v to beat %v", full, pair)
+	}
+	hands := hand.Sort(hand.SortingHigh, hand.DESC, pair, full, trips)
+	if hands[0] != trips || hands[1] != full || hands[2] != pair {
+		t.Fatalf("expected sort order %v, %v, %v got %v", trips, full, pair, hands)
+	}
+}
+
+func TestValueAceToFiveLow(t *testing.T) {
+	// aces are low so an ace is kept over a king
+	h1 := hand.New(Cards("8d", "7s", "6s", "5s", "Kh", "Ah", "Kc"), hand.AceToFiveLow)
+	if h1.Description() != "high card eight high" || h1.Cards()[4].Rank() != hand.Ace {
+		t.Fatalf("expected eight seven six five ace low got %v", h1)
+	}
+	// a pair of aces is the lowest pair
+	h2 := hand.New(Cards("As", "Ad", "4s", "3s", "2s"), hand.AceToFiveLow)
+	h3 := hand.New(Cards("2d", "2h", "4s", "3s", "5s"), hand.AceToFiveLow)
+	if h2.Value() <= h3.Value() {
+		t.Fatalf("expected %v to beat %v", h2, h3)
+	}
+	hands := hand.Sort(hand.SortingLow, hand.DESC, h3, h1, h2)
+	if hands[0] != h1 || hands[1] != h2 || hands[2] != h3 {
+		t.Fatalf("expected low sort order %v, %v, %v got %v", h1, h2, h3, hands)
+	}
+}
+
+func TestValueShortDeck(t *testing.T) {
+	flush := hand.New(Cards("Ks", "9s", "8s", "7s", "6s"), hand.ShortDeck)
+	fullHouse := hand.New(Cards("As", "Ad", "Ah", "Ks", "Kd"), hand.ShortDeck)
+	if flush.Value() <= fullHouse.Value() {
+		t.Fatalf("expected %v to beat %v", flush, fullHouse)
+	}
+	// the 36 card deck has no hands with a two through five
+	if n := hand.ValueCount(hand.ShortDeck); n != 1404 {
+		t.Fatalf("expected short deck to have %d values got %d", 1404, n)
+	}
+	if flush.Value() > 1404 || fullHouse.Value() > 1404 {
+		t.Fatalf("expected short deck values of at most %d got %d and %d", 1404, flush.Value(), fullHouse.Value())
+	}
+}
+
+func TestShortDeckDeuce(t *testing.T) {
+	// deuces aren't in the short deck but are still evaluated
+	h := hand.New(Cards("Ks", "Kd", "Kh", "2s", "9c", "8d", "7h"), hand.ShortDeck)
+	trips := hand.New(Cards("Ks", "Kd", "Kh", "9c", "8d"), hand.ShortDeck)
+	if h.Description() != "three of a kind kings" || h.Value() != trips.Value() {
+		t.Fatalf("expected %v got %v", trips, h)
+	}
+	twoPair := hand.New(Cards("Ks", "Kd", "2s", "2d", "9c"), hand.ShortDeck)
+	if twoPair.Ranking() != hand.SDTwoPair || twoPair.Description() != "two pair kings and twos" {
+		t.Fatalf("expected two pair kings and twos got %v", twoPair)
+	}
+	if twoPair.Value() != 0 {
+		t.Fatalf("expected %v to have no value got %d", twoPair, twoPair.Value())
+	}
+	if trips.CompareTo(twoPair) <= 0 {
+		t.Fatalf("expected %v to beat %v", trips, twoPair)
+	}
+	omaha := hand.NewOmaha(Cards("2s", "2d", "Ah", "Kc"), Cards("2h", "9c", "8d"), hand.ShortDeck)
+	if omaha.Ranking() != hand.SDThreeOfAKind {
+		t.Fatalf("expected three of a kind twos got %v", omaha)
+	}
+}
+
+func TestValueJSON(t *testing.T) {
+	h := hand.New(Cards("Ks", "9s", "8s", "7s", "6s"), hand.ShortDeck)
+	b, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := &hand.Hand{}
+	if err := json.Unmarshal(b, cp); err != nil {
+		t.Fatal(err)
+	}
+	if cp.Value() != h.Value() {
+		t.Fatalf("expected value %d after json round trip got %d", h.Value(), cp.Value())
+	}
+}
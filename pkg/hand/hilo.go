@@ -0,0 +1,167 @@
+package hand
+
+import (
+	"fmt"
+)
+
+// eightOrBetterRanks holds the ranks a qualifying low may be made of.
+const eightOrBetterRanks uint16 = 1<<uint(Ace) | 1<<uint(Two) | 1<<uint(Three) |
+	1<<uint(Four) | 1<<uint(Five) | 1<<uint(Six) | 1<<uint(Seven) | 1<<uint(Eight)
+
+// HiLo is the result of evaluating cards for a hi/lo split pot game
+// played eight or better.  High is always present and Low is nil if the
+// cards don't make a qualifying low.
+type HiLo struct {
+	High *Hand
+	Low  *Hand
+}
+
+// NewHiLo forms the high hand and the qualifying low hand from the given
+// cards.  Like New, the high hand is the best five card combination of
+// the cards formed with the given options.  The low hand is the best
+// ace-to-five low of five cards of different ranks, all eight or lower,
+// and is nil if there isn't one.  Wild cards, including jokers, are used
+// in the low as the lowest ranks it doesn't already have.
+func NewHiLo(cards []Card, options ...func(*Config)) *HiLo {
+	low := eightOrBetterLow(options, func(f func(c0, c1, c2, c3, c4 Card)) {
+		forEachCombo(cards, f)
+	})
+	return &HiLo{
+		High: New(cards, options...),
+		Low:  low,
+	}
+}
+
+// NewOmahaHiLo forms the high hand and the qualifying low hand from the
+// given hole and board cards in the same way as NewHiLo, except that
+// both hands must use exactly two hole cards and three board cards.  The
+// high and low hands may use different hole cards.  Like NewOmaha, the
+// high hand has less than five cards if there are less than two hole
+// cards or three board cards, and there is no low.
+func NewOmahaHiLo(hole, board []Card, options ...func(*Config)) *HiLo {
+	high := NewOmaha(hole, board, options...)
+	low := eightOrBetterLow(options, func(f func(c0, c1, c2, c3, c4 Card)) {
+		forEachOmahaCombo(hole, board, f)
+	})
+	return &HiLo{
+		High: high,
+		Low:  low,
+	}
+}
+
+// HasLow returns true if the cards make a qualifying low.
+func (h *HiLo) HasLow() bool {
+	return h.Low != nil
+}
+
+// CompareLow returns a positive value if this low beats the other low, a
+// negative value if this low loses to the other low, and zero if the lows
+// are equal or neither qualifies.  Any qualifying low beats no low.
+func (h *HiLo) CompareLow(o *HiLo) int {
+	switch {
+	case h.Low == nil && o.Low == nil:
+		return 0
+	case o.Low == nil:
+		return 1
+	case h.Low == nil:
+		return -1
+	}
+	return h.Low.Value() - o.Low.Value()
+}
+
+// LowDescription returns a user displayable description of the low such
+// as "eight-six low" or "no low" if the cards don't make a qualifying low.
+func (h *HiLo) LowDescription() string {
+	return h.LocalLowDescription(English)
+}
+
+// LocalLowDescription returns the description of the low in the language
+// of the locale.
+func (h *HiLo) LocalLowDescription(l *Locale) string {
+	if h.Low == nil {
+		return l.phrase("no low")
+	}
+	d := lowDescription(h.Low.formedCards())
+	d.Wilds = h.Low.WildCards()
+	return d.Render(l)
+}
+
+// Description returns a user displayable description of both hands such
+// as "flush ace high / eight-six low".
+func (h *HiLo) Description() string {
+	return h.LocalDescription(English)
+}
+
+// LocalDescription returns the description of both hands in the language
+// of the locale.
+func (h *HiLo) LocalDescription(l *Locale) string {
+	return h.High.LocalDescription(l) + " / " + h.LocalLowDescription(l)
+}
+
+// String returns the description followed by the cards used.
+func (h *HiLo) String() string {
+	if h.Low == nil {
+		return fmt.Sprintf("%s %v / %s", h.High.Description(), h.High.Cards(), h.LowDescription())
+	}
+	return fmt.Sprintf("%s %v / %s %v", h.High.Description(), h.High.Cards(), h.LowDescription(), h.Low.Cards())
+}
+
+// eightOrBetterSubs returns the cards with each wild card replaced by the
+// lowest rank not already in them with aces low, and false unless the
+// result is five different ranks that are all eight or lower.
+func eightOrBetterSubs(cards [5]Card, c *Config) ([5]Card, bool) {
+	var used uint16
+	for _, card := range cards {
+		if c.isWild(card) {
+			continue
+		}
+		r := uint16(1) << uint(card.Rank())
+		if used&r != 0 || r&^eightOrBetterRanks != 0 {
+			return cards, false
+		}
+		used |= r
+	}
+	subs := cards
+	ranks := allAceLowRanks()
+	next := 0
+	for i, card := range cards {
+		if !c.isWild(card) {
+			continue
+		}
+		for used&(1<<uint(ranks[next])) != 0 {
+			next++
+		}
+		used |= 1 << uint(ranks[next])
+		subs[i] = getCard(ranks[next], Spades)
+	}
+	return subs, true
+}
+
+// eightOrBetterLow returns the best qualifying low out of the combinations
+// that walk passes to its function or nil if none qualify.  Wild cards
+// under the options are used as the lowest ranks missing from the low.
+func eightOrBetterLow(options []func(*Config), walk func(f func(c0, c1, c2, c3, c4 Card))) *Hand {
+	w := &Config{}
+	for _, option := range options {
+		option(w)
+	}
+	c := &Config{}
+	AceToFiveLow(c)
+	t := tableFor(c)
+	var value uint16
+	var cards, subs [5]Card
+	walk(func(c0, c1, c2, c3, c4 Card) {
+		combo := [5]Card{c0, c1, c2, c3, c4}
+		s, ok := eightOrBetterSubs(combo, w)
+		if !ok {
+			return
+		}
+		if v := t.lookup(s[0], s[1], s[2], s[3], s[4]); value == 0 || v < value {
+			value, cards, subs = v, combo, s
+		}
+	})
+	if value == 0 {
+		return nil
+	}
+	return t.wildHand(cards, subs, value, c)
+}
@@ -0,0 +1,98 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+type hiLoTest struct {
+	hole        []hand.Card
+	board       []hand.Card
+	omaha       bool
+	description string
+}
+
+var hiLoTests = []hiLoTest{
+	{
+		Cards("As", "2s"),
+		Cards("3s", "4d", "5s", "Ks", "Kd"),
+		false,
+		"flush ace high / five-four low",
+	},
+	{
+		Cards("As", "Kh"),
+		Cards("9s", "4d", "5s", "Ks", "Kd"),
+		false,
+		"three of a kind kings / no low",
+	},
+	{
+		Cards("8c", "7h"),
+		Cards("6s", "4d", "2s", "2h", "Qd"),
+		false,
+		"pair of twos / eight-seven low",
+	},
+	{
+		Cards("As", "2s", "Kh", "Kd"),
+		Cards("3c", "4d", "9s", "Ks", "Qd"),
+		true,
+		"three of a kind kings / no low",
+	},
+	{
+		Cards("As", "2s", "Kh", "Kd"),
+		Cards("3c", "4d", "8s", "Ks", "Qd"),
+		true,
+		"three of a kind kings / eight-four low",
+	},
+}
+
+func TestHiLo(t *testing.T) {
+	for _, test := range hiLoTests {
+		var h *hand.HiLo
+		if test.omaha {
+			h = hand.NewOmahaHiLo(test.hole, test.board)
+		} else {
+			h = hand.NewHiLo(append(test.hole, test.board...))
+		}
+		if h.Description() != test.description {
+			t.Fatalf("expected \"%v\" got \"%v\"", test.description, h.Description())
+		}
+	}
+}
+
+func TestCompareLow(t *testing.T) {
+	wheel := hand.NewHiLo(Cards("As", "2s", "3s", "4d", "5s", "Ks", "Kd"))
+	eight := hand.NewHiLo(Cards("8c", "7h", "6s", "4d", "2s", "2h", "Qd"))
+	none := hand.NewHiLo(Cards("As", "Kh", "9s", "4d", "5s", "Ks", "Kd"))
+	if wheel.CompareLow(eight) <= 0 || eight.CompareLow(wheel) >= 0 {
+		t.Fatalf("expected %v to beat %v", wheel, eight)
+	}
+	if eight.CompareLow(none) <= 0 || none.CompareLow(eight) >= 0 {
+		t.Fatalf("expected %v to beat %v", eight, none)
+	}
+	if none.CompareLow(none) != 0 || none.HasLow() {
+		t.Fatalf("expected %v to have no low", none)
+	}
+}
+
+func TestHiLoWild(t *testing.T) {
+	// the joker is the lowest card the low is missing
+	h := hand.NewHiLo(append(Cards("As", "2d", "5c", "8h", "Kd", "Kh"), hand.BlackJoker))
+	if h.LowDescription() != "eight-five low (🃏 as three)" {
+		t.Fatalf("expected \"eight-five low (🃏 as three)\" got %q", h.LowDescription())
+	}
+	natural := hand.NewHiLo(Cards("As", "2d", "3c", "5c", "8h", "Kd", "Kh"))
+	if h.CompareLow(natural) != 0 {
+		t.Fatalf("expected %v to tie %v", h, natural)
+	}
+	// deuces wild are used as the lowest ranks the low is missing
+	h = hand.NewHiLo(Cards("As", "Ad", "2c", "2h", "6s", "4d", "Qh"), hand.Wild(hand.Two))
+	if h.LowDescription() != "six-four low (2♥ as three, 2♣ as two)" {
+		t.Fatalf("expected \"six-three low (2♣ as two, 2♥ as three)\" got %q", h.LowDescription())
+	}
+	omaha := hand.NewOmahaHiLo(append(Cards("Ah", "Kc", "Kd"), hand.RedJoker), Cards("4s", "7d", "8c", "Qh", "Jh"))
+	if !omaha.HasLow() || omaha.LowDescription() != "eight-seven low (🂿 as two)" {
+		t.Fatalf("expected an eight-seven low got %v", omaha)
+	}
+}
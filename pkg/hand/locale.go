@@ -0,0 +1,437 @@
+package hand
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate stringer -type=DescriptionKind -trimprefix=Desc -output=description_string.go locale.go
+
+// A DescriptionKind is the form of a hand's description regardless of the
+// ranking scheme that ranks it.  String returns the name of the kind such
+// as "FullHouse", which names the ranking of a hand in any scheme.
+type DescriptionKind int
+
+const (
+	// DescHighCard describes a hand by its highest rank such as "high
+	// card ace high".
+	DescHighCard DescriptionKind = iota + 1
+
+	// DescPair describes a hand by its pair such as "pair of queens".
+	DescPair
+
+	// DescTwoPair describes a hand by its top and second pair such as
+	// "two pair queens and twos".
+	DescTwoPair
+
+	// DescThreeOfAKind describes a hand by its three of a kind such as
+	// "three of a kind sixes".
+	DescThreeOfAKind
+
+	// DescStraight describes a hand by the highest rank of its straight
+	// such as "straight ace high".
+	DescStraight
+
+	// DescFlush describes a hand by the highest rank of its flush such as
+	// "flush seven high".
+	DescFlush
+
+	// DescFullHouse describes a hand by its three of a kind and pair such
+	// as "full house kings full of sixes".
+	DescFullHouse
+
+	// DescFourOfAKind describes a hand by its four of a kind such as "four
+	// of a kind aces".
+	DescFourOfAKind
+
+	// DescStraightFlush describes a hand by the highest rank of its
+	// straight flush such as "straight flush nine high".
+	DescStraightFlush
+
+	// DescRoyalFlush describes a royal flush.
+	DescRoyalFlush
+
+	// DescFiveOfAKind describes a hand by its five of a kind such as
+	// "five of a kind aces".
+	DescFiveOfAKind
+
+	// DescLow describes a low hand by its two highest ranks such as
+	// "seven-five low".
+	DescLow
+
+	// DescBadugi describes a four card badugi by its ranks such as
+	// "8-5-3-A badugi".
+	DescBadugi
+
+	// DescThreeCardBadugi describes a three card badugi hand by its ranks
+	// such as "8-5-3 three-card hand".
+	DescThreeCardBadugi
+
+	// DescTwoCardBadugi describes a two card badugi hand by its ranks
+	// such as "5-3 two-card hand".
+	DescTwoCardBadugi
+
+	// DescOneCardBadugi describes a one card badugi hand by its rank such
+	// as "3 one-card hand".
+	DescOneCardBadugi
+
+	// DescText describes a hand with the Text of the description, which
+	// is the same in every locale.  It's used by rankings described with
+	// a DescFunc.
+	DescText
+)
+
+// A Description is a structured description of a hand that can be
+// rendered in any language with a Locale.  Ranks holds the ranks the
+// description names in order, such as the three of a kind and then the
+// pair of a full house, and Wilds the wild cards of the hand.  Kickers
+// holds the ranks after the ones the description names that break ties
+// between hands of the same description, such as the three kickers of a
+// pair or the other cards of a flush.  Text is only set for DescText
+// descriptions.
+type Description struct {
+	Kind    DescriptionKind
+	Ranking Ranking
+	Ranks   []Rank
+	Kickers []Rank
+	Wilds   []WildCard
+	Text    string
+}
+
+// DescribeAs returns a DescriptionFunc that describes formed cards with
+// the kind and the ranks of the cards at the given positions, for example
+// DescribeAs(DescTwoPair, 0, 2) for the two pairs of a two pair hand.
+func DescribeAs(kind DescriptionKind, positions ...int) DescriptionFunc {
+	return func(cards []Card) Description {
+		d := Description{Kind: kind}
+		for _, i := range positions {
+			d.Ranks = append(d.Ranks, cards[i].Rank())
+		}
+		return d
+	}
+}
+
+// DescribeText adapts a DescFunc to a DescriptionFunc that describes
+// formed cards as DescText with the text returned by dFunc.
+func DescribeText(dFunc DescFunc) DescriptionFunc {
+	return func(cards []Card) Description {
+		return Description{Kind: DescText, Text: dFunc(cards)}
+	}
+}
+
+// String returns the description rendered in English.
+func (d Description) String() string {
+	return d.Render(English)
+}
+
+// Render returns the description in the language of the locale, such as
+// "pair of queens, king-jack-nine kickers".  The kickers of a low
+// continue its ranks, as in "seven-five-four-three-two low".  Kinds
+// missing from the locale's formats are rendered in English.
+func (d Description) Render(l *Locale) string {
+	format, ok := l.Formats[d.Kind]
+	if !ok {
+		l, format = English, English.Formats[d.Kind]
+	}
+	symbols := make([]string, len(d.Ranks))
+	args := []string{}
+	for i, r := range d.Ranks {
+		symbols[i] = r.String()
+		n := strconv.Itoa(i + 1)
+		name := l.RankName(r)
+		if d.Kind == DescLow && i == len(d.Ranks)-1 && len(d.Kickers) > 0 {
+			name += "-" + rankNames(l, d.Kickers)
+		}
+		args = append(args, "{"+n+"}", name, "{"+n+"s}", l.PluralRankName(r))
+	}
+	args = append(args, "{ranks}", strings.Join(symbols, "-"))
+	s := strings.NewReplacer(args...).Replace(format)
+	switch {
+	case d.Kind == DescText:
+		s = d.Text
+	case d.Kind == DescLow || len(d.Kickers) == 0:
+	case d.Kind == DescFlush:
+		s += ", " + rankNames(l, d.Kickers)
+	case len(d.Kickers) == 1:
+		s += ", " + l.phrase("{kicker} kicker", "{kicker}", l.RankName(d.Kickers[0]))
+	default:
+		s += ", " + l.phrase("{kickers} kickers", "{kickers}", rankNames(l, d.Kickers))
+	}
+	if len(d.Wilds) == 0 {
+		return s
+	}
+	flush := d.Kind == DescFlush || d.Kind == DescStraightFlush || d.Kind == DescRoyalFlush
+	wilds := make([]string, len(d.Wilds))
+	for i, w := range d.Wilds {
+		as := l.RankName(w.As.Rank())
+		if flush {
+			as = w.As.String()
+		}
+		wilds[i] = strings.NewReplacer("{wild}", w.Wild.String(), "{as}", as).Replace(l.Wild)
+	}
+	return s + " (" + strings.Join(wilds, ", ") + ")"
+}
+
+// A Locale is a catalog of the words and formats used to describe hands
+// and cards in one language.  Ranks and Plurals are indexed by Rank and
+// include the joker, Suits is indexed by Suit, and Jokers holds the
+// names of the black and red jokers.  Formats replace "{1}" and "{1s}"
+// with the singular and plural names of the first rank of a Description,
+// "{2}" and "{2s}" with the second, and "{ranks}" with the rank symbols
+// joined by dashes.  Wild replaces "{wild}" with a wild card and "{as}"
+// with what it was used as, and Card replaces "{rank}" and "{suit}" with
+// the names of a card's rank and suit.  Phrases translates the rest of
+// the text used to describe and compare hands, such as the kickers of
+// descriptions, "no low", and explanations, keyed by the English phrase.
+// Phrases missing from the map are used in English.
+type Locale struct {
+	Tag     string
+	Ranks   []string
+	Plurals []string
+	Suits   []string
+	Jokers  []string
+	Formats map[DescriptionKind]string
+	Wild    string
+	Card    string
+	Phrases map[string]string
+}
+
+// RankName returns the singular name of the rank such as "queen".
+func (l *Locale) RankName(r Rank) string {
+	return l.Ranks[r]
+}
+
+// PluralRankName returns the plural name of the rank such as "queens".
+func (l *Locale) PluralRankName(r Rank) string {
+	return l.Plurals[r]
+}
+
+// phrase returns the translation of the English phrase, or the phrase
+// itself if the locale doesn't translate it, with its placeholders
+// replaced by the old and new string pairs of args.
+func (l *Locale) phrase(english string, args ...string) string {
+	s, ok := l.Phrases[english]
+	if !ok {
+		s = english
+	}
+	return strings.NewReplacer(args...).Replace(s)
+}
+
+// CardName returns the full name of the card such as "queen of hearts".
+func (l *Locale) CardName(c Card) string {
+	switch c {
+	case BlackJoker:
+		return l.Jokers[0]
+	case RedJoker:
+		return l.Jokers[1]
+	}
+	return strings.NewReplacer("{rank}", l.RankName(c.Rank()), "{suit}", l.Suits[c.Suit()]).Replace(l.Card)
+}
+
+var (
+	// English is the default locale, used by Hand.Description.
+	English = &Locale{
+		Tag:     "en",
+		Ranks:   singularNames,
+		Plurals: pluralNames,
+		Suits:   []string{"spades", "hearts", "diamonds", "clubs"},
+		Jokers:  []string{"black joker", "red joker"},
+		Formats: map[DescriptionKind]string{
+			DescHighCard:        "high card {1} high",
+			DescPair:            "pair of {1s}",
+			DescTwoPair:         "two pair {1s} and {2s}",
+			DescThreeOfAKind:    "three of a kind {1s}",
+			DescStraight:        "straight {1} high",
+			DescFlush:           "flush {1} high",
+			DescFullHouse:       "full house {1s} full of {2s}",
+			DescFourOfAKind:     "four of a kind {1s}",
+			DescStraightFlush:   "straight flush {1} high",
+			DescRoyalFlush:      "royal flush",
+			DescFiveOfAKind:     "five of a kind {1s}",
+			DescLow:             "{1}-{2} low",
+			DescBadugi:          "{ranks} badugi",
+			DescThreeCardBadugi: "{ranks} three-card hand",
+			DescTwoCardBadugi:   "{ranks} two-card hand",
+			DescOneCardBadugi:   "{ranks} one-card hand",
+		},
+		Wild: "{wild} as {as}",
+		Card: "{rank} of {suit}",
+	}
+
+	// Vietnamese is the Vietnamese locale.
+	Vietnamese = &Locale{
+		Tag:     "vi",
+		Ranks:   []string{"hai", "ba", "bốn", "năm", "sáu", "bảy", "tám", "chín", "mười", "bồi", "đầm", "già", "át", "phăng teo"},
+		Plurals: []string{"hai", "ba", "bốn", "năm", "sáu", "bảy", "tám", "chín", "mười", "bồi", "đầm", "già", "át", "phăng teo"},
+		Suits:   []string{"bích", "cơ", "rô", "chuồn"},
+		Jokers:  []string{"phăng teo đen", "phăng teo đỏ"},
+		Formats: map[DescriptionKind]string{
+			DescHighCard:        "mậu thầu {1}",
+			DescPair:            "đôi {1s}",
+			DescTwoPair:         "thú {1s} và {2s}",
+			DescThreeOfAKind:    "sám cô {1s}",
+			DescStraight:        "sảnh tới {1}",
+			DescFlush:           "thùng tới {1}",
+			DescFullHouse:       "cù lũ {1s} đôi {2s}",
+			DescFourOfAKind:     "tứ quý {1s}",
+			DescStraightFlush:   "thùng phá sảnh tới {1}",
+			DescRoyalFlush:      "thùng phá sảnh hoàng gia",
+			DescFiveOfAKind:     "ngũ quý {1s}",
+			DescLow:             "{1}-{2} thấp",
+			DescBadugi:          "{ranks} badugi",
+			DescThreeCardBadugi: "{ranks} bài ba lá",
+			DescTwoCardBadugi:   "{ranks} bài hai lá",
+			DescOneCardBadugi:   "{ranks} bài một lá",
+		},
+		Wild: "{wild} thay {as}",
+		Card: "{rank} {suit}",
+		Phrases: map[string]string{
+			"{kicker} kicker":        "kicker {kicker}",
+			"{kickers} kickers":      "kicker {kickers}",
+			"no low":                 "không có bài thấp",
+			"tie, both have {hand}":  "hòa, cả hai có {hand}",
+			"{winner} beats {loser}": "{winner} thắng {loser}",
+			"same {name}, wins on {nth} {unit} {winning} vs {losing}": "cùng {name}, thắng nhờ {unit} thứ {nth} {winning} so với {losing}",
+			"same {first}, {better} {part} {winning} vs {losing}":     "cùng {first}, {part} {better} {winning} so với {losing}",
+			"{better} {part} {winning} vs {losing}":                   "{part} {better} {winning} so với {losing}",
+			"higher":                                                  "cao hơn",
+			"lower":                                                   "thấp hơn",
+			"kicker":                                                  "kicker",
+			"card":                                                    "lá",
+			"first":                                                   "nhất",
+			"second":                                                  "hai",
+			"third":                                                   "ba",
+			"fourth":                                                  "tư",
+			"fifth":                                                   "năm",
+			"straight":                                                "sảnh",
+			"flush":                                                   "thùng",
+			"{1}-high flush":                                          "thùng tới {1}",
+			"{1} low":                                                 "{1} thấp",
+			"{1} high":                                                "mậu thầu {1}",
+			"five of a kind":                                          "ngũ quý",
+			"four of a kind":                                          "tứ quý",
+			"full house":                                              "cù lũ",
+			"three of a kind":                                         "sám cô",
+			"two pair":                                                "thú",
+			"pair":                                                    "đôi",
+			"top pair":                                                "đôi trên",
+			"second pair":                                             "đôi dưới",
+		},
+	}
+
+	// Spanish is the Spanish locale.
+	Spanish = &Locale{
+		Tag:     "es",
+		Ranks:   []string{"dos", "tres", "cuatro", "cinco", "seis", "siete", "ocho", "nueve", "diez", "jota", "reina", "rey", "as", "comodín"},
+		Plurals: []string{"doses", "treses", "cuatros", "cincos", "seises", "sietes", "ochos", "nueves", "dieces", "jotas", "reinas", "reyes", "ases", "comodines"},
+		Suits:   []string{"picas", "corazones", "diamantes", "tréboles"},
+		Jokers:  []string{"comodín negro", "comodín rojo"},
+		Formats: map[DescriptionKind]string{
+			DescHighCard:        "carta alta {1}",
+			DescPair:            "pareja de {1s}",
+			DescTwoPair:         "doble pareja de {1s} y {2s}",
+			DescThreeOfAKind:    "trío de {1s}",
+			DescStraight:        "escalera al {1}",
+			DescFlush:           "color al {1}",
+			DescFullHouse:       "full de {1s} con {2s}",
+			DescFourOfAKind:     "póquer de {1s}",
+			DescStraightFlush:   "escalera de color al {1}",
+			DescRoyalFlush:      "escalera real",
+			DescFiveOfAKind:     "repóquer de {1s}",
+			DescLow:             "{1}-{2} baja",
+			DescBadugi:          "{ranks} badugi",
+			DescThreeCardBadugi: "{ranks} mano de tres cartas",
+			DescTwoCardBadugi:   "{ranks} mano de dos cartas",
+			DescOneCardBadugi:   "{ranks} mano de una carta",
+		},
+		Wild: "{wild} como {as}",
+		Card: "{rank} de {suit}",
+		Phrases: map[string]string{
+			"{kicker} kicker":        "kicker {kicker}",
+			"{kickers} kickers":      "kickers {kickers}",
+			"no low":                 "sin baja",
+			"tie, both have {hand}":  "empate, ambos tienen {hand}",
+			"{winner} beats {loser}": "{winner} gana a {loser}",
+			"same {name}, wins on {nth} {unit} {winning} vs {losing}": "{name} igual, gana por {unit} n.º {n} {winning} contra {losing}",
+			"same {first}, {better} {part} {winning} vs {losing}":     "{first} igual, {part} {better} {winning} contra {losing}",
+			"{better} {part} {winning} vs {losing}":                   "{part} {better} {winning} contra {losing}",
+			"higher":                                                  "mayor",
+			"lower":                                                   "menor",
+			"kicker":                                                  "kicker",
+			"card":                                                    "carta",
+			"straight":                                                "escalera",
+			"flush":                                                   "color",
+			"{1}-high flush":                                          "color al {1}",
+			"{1} low":                                                 "{1} baja",
+			"{1} high":                                                "carta alta {1}",
+			"five of a kind":                                          "repóquer",
+			"four of a kind":                                          "póquer",
+			"full house":                                              "full",
+			"three of a kind":                                         "trío",
+			"two pair":                                                "doble pareja",
+			"pair":                                                    "pareja",
+			"top pair":                                                "pareja alta",
+			"second pair":                                             "segunda pareja",
+		},
+	}
+)
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]*Locale{"en": English, "vi": Vietnamese, "es": Spanish}
+)
+
+// RegisterLocale adds the locale to the catalog under its tag, replacing
+// any locale with the same tag.
+func RegisterLocale(l *Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[l.Tag] = l
+}
+
+// LookupLocale returns the locale registered under the tag such as "vi".
+// LookupLocale returns English and false if there is no such locale.
+func LookupLocale(tag string) (*Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	l, ok := locales[tag]
+	if !ok {
+		return English, false
+	}
+	return l, true
+}
+
+// Describe returns the structured description of the hand, including
+// its kickers.
+func (h *Hand) Describe() Description {
+	d := h.desc
+	d.Ranks = append([]Rank{}, d.Ranks...)
+	d.Kickers = h.kickers()
+	d.Wilds = h.WildCards()
+	return d
+}
+
+// LocalFullDescription returns the description of the hand in the
+// language of the locale followed by its kickers, like FullDescription.
+func (h *Hand) LocalFullDescription(l *Locale) string {
+	return h.Describe().Render(l)
+}
+
+// LocalDescription returns the description of the hand in the language
+// of the locale without its kickers, like Description.
+func (h *Hand) LocalDescription(l *Locale) string {
+	d := h.Describe()
+	d.Kickers = nil
+	return d.Render(l)
+}
+
+// rankNames joins the singular names of the ranks in the locale such as
+// "king-jack-nine".
+func rankNames(l *Locale, ranks []Rank) string {
+	names := make([]string, len(ranks))
+	for i, r := range ranks {
+		names[i] = l.RankName(r)
+	}
+	return strings.Join(names, "-")
+}
@@ -0,0 +1,135 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestLocalDescription(t *testing.T) {
+	tests := []struct {
+		cards   []hand.Card
+		options []func(*hand.Config)
+		en      string
+		vi      string
+		es      string
+	}{
+		{Cards("Ks", "Qh", "Qs", "Js", "9d"), nil, "pair of queens", "đôi đầm", "pareja de reinas"},
+		{Cards("2s", "Qh", "Qs", "Js", "2d"), nil, "two pair queens and twos", "thú đầm và hai", "doble pareja de reinas y doses"},
+		{Cards("6s", "6h", "Ks", "Kh", "6d"), nil, "full house sixes full of kings", "cù lũ sáu đôi già", "full de seises con reyes"},
+		{Cards("Ah", "Kh", "Qh", "Jh", "Th"), nil, "royal flush", "thùng phá sảnh hoàng gia", "escalera real"},
+		{Cards("7s", "5h", "4d", "3c", "2s"), []func(*hand.Config){hand.DeuceToSevenLow}, "seven-five low", "bảy-năm thấp", "siete-cinco baja"},
+		{Cards("8s", "5h", "3d", "Ac"), []func(*hand.Config){hand.Badugi}, "8-5-3-A badugi", "8-5-3-A badugi", "8-5-3-A badugi"},
+		{Cards("2s", "As", "Ad", "Ac", "Kh"), []func(*hand.Config){hand.Wild(hand.Two)}, "four of a kind aces (2♠ as ace)", "tứ quý át (2♠ thay át)", "póquer de ases (2♠ como as)"},
+	}
+	for _, test := range tests {
+		h := hand.New(test.cards, test.options...)
+		if h.Description() != test.en || h.LocalDescription(hand.English) != test.en {
+			t.Fatalf("expected %q got %q", test.en, h.Description())
+		}
+		if d := h.LocalDescription(hand.Vietnamese); d != test.vi {
+			t.Fatalf("expected %q got %q", test.vi, d)
+		}
+		if d := h.LocalDescription(hand.Spanish); d != test.es {
+			t.Fatalf("expected %q got %q", test.es, d)
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+	h := hand.New(Cards("Ks", "Qh", "Qs", "Js", "9d"))
+	d := h.Describe()
+	if d.Kind != hand.DescPair || d.Ranking != hand.StdPair || len(d.Ranks) != 1 || d.Ranks[0] != hand.Queen {
+		t.Fatalf("expected a pair of queens got %+v", d)
+	}
+	if len(d.Kickers) != 3 || d.Kickers[0] != hand.King || d.Kickers[1] != hand.Jack || d.Kickers[2] != hand.Nine {
+		t.Fatalf("expected king-jack-nine kickers got %v", d.Kickers)
+	}
+	pirate := &hand.Locale{
+		Tag:     "en-pirate",
+		Ranks:   hand.English.Ranks,
+		Plurals: hand.English.Plurals,
+		Suits:   []string{"cutlasses", "hearts", "doubloons", "clubs"},
+		Jokers:  hand.English.Jokers,
+		Formats: map[hand.DescriptionKind]string{hand.DescPair: "a brace o' {1s}"},
+		Card:    "{rank} o' {suit}",
+	}
+	hand.RegisterLocale(pirate)
+	l, ok := hand.LookupLocale("en-pirate")
+	if !ok || h.LocalDescription(l) != "a brace o' queens" {
+		t.Fatalf("expected the registered locale got %v %q", ok, h.LocalDescription(l))
+	}
+	if s := hand.New(Cards("As", "Ks", "Qs", "Js", "9s")).LocalDescription(l); s != "flush ace high" {
+		t.Fatalf("expected missing formats to be rendered in english got %q", s)
+	}
+	if l, ok := hand.LookupLocale("xx"); ok || l != hand.English {
+		t.Fatalf("expected english for an unknown locale")
+	}
+	cards := []struct {
+		card hand.Card
+		l    *hand.Locale
+		name string
+	}{
+		{hand.AceSpades, hand.English, "ace of spades"},
+		{hand.QueenHearts, hand.Spanish, "reina de corazones"},
+		{hand.TenDiamonds, hand.Vietnamese, "mười rô"},
+		{hand.RedJoker, hand.English, "red joker"},
+		{hand.AceSpades, pirate, "ace o' cutlasses"},
+	}
+	for _, test := range cards {
+		if name := test.l.CardName(test.card); name != test.name {
+			t.Fatalf("expected %q got %q", test.name, name)
+		}
+	}
+}
+
+func TestLocalPhrases(t *testing.T) {
+	pair := hand.New(Cards("Ks", "Qh", "Qs", "Js", "9d"))
+	kicker := hand.Explain(hand.New(Cards("Qs", "Qh", "Ks", "Jd", "9c")), hand.New(Cards("Qd", "Qc", "Kh", "Tc", "9s")))
+	higher := hand.Explain(hand.New(Cards("Js", "Jh", "As", "Kd", "9c")), hand.New(Cards("Qd", "Qc", "2h", "3c", "4s")))
+	hilo := hand.NewHiLo(Cards("As", "Ks", "Qs", "Js", "9d"))
+	tests := []struct {
+		l        *hand.Locale
+		full     string
+		kicker   string
+		higher   string
+		hiloDesc string
+	}{
+		{
+			hand.English,
+			"pair of queens, king-jack-nine kickers",
+			"same pair, wins on second kicker J vs T",
+			"higher pair Q vs J",
+			"high card ace high / no low",
+		},
+		{
+			hand.Vietnamese,
+			"đôi đầm, kicker già-bồi-chín",
+			"cùng đôi, thắng nhờ kicker thứ hai J so với T",
+			"đôi cao hơn Q so với J",
+			"mậu thầu át / không có bài thấp",
+		},
+		{
+			hand.Spanish,
+			"pareja de reinas, kickers rey-jota-nueve",
+			"pareja igual, gana por kicker n.º 2 J contra T",
+			"pareja mayor Q contra J",
+			"carta alta as / sin baja",
+		},
+	}
+	for _, test := range tests {
+		if s := pair.LocalFullDescription(test.l); s != test.full {
+			t.Fatalf("expected %q got %q", test.full, s)
+		}
+		if s := kicker.Render(test.l); s != test.kicker {
+			t.Fatalf("expected %q got %q", test.kicker, s)
+		}
+		if s := higher.Render(test.l); s != test.higher {
+			t.Fatalf("expected %q got %q", test.higher, s)
+		}
+		if s := hilo.LocalDescription(test.l); s != test.hiloDesc {
+			t.Fatalf("expected %q got %q", test.hiloDesc, s)
+		}
+	}
+}
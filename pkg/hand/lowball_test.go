@@ -0,0 +1,94 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+// deuceToSevenOrder lists deuce-to-seven lows from best to worst.
+var deuceToSevenOrder = [][]hand.Card{
+	Cards("7s", "5d", "4c", "3h", "2s"),
+	Cards("7s", "6d", "4c", "3h", "2s"),
+	Cards("7s", "6d", "5c", "3h", "2s"),
+	Cards("7s", "6d", "5c", "4h", "2s"),
+	Cards("8s", "5d", "4c", "3h", "2s"),
+	Cards("8s", "7d", "6c", "5h", "3s"),
+	Cards("Ks", "Qd", "Jc", "Th", "8s"),
+	Cards("As", "5d", "4c", "3h", "2s"),
+	Cards("As", "Kd", "Qc", "Jh", "9s"),
+	Cards("2s", "2d", "5c", "4h", "3s"),
+	Cards("Ks", "Kd", "Qc", "Jh", "Ts"),
+	Cards("2s", "2d", "3c", "3h", "4s"),
+	Cards("2s", "2d", "2c", "3h", "4s"),
+	Cards("6s", "5d", "4c", "3h", "2s"),
+	Cards("As", "Kd", "Qc", "Jh", "Ts"),
+	Cards("7s", "5s", "4s", "3s", "2s"),
+	Cards("As", "5s", "4s", "3s", "2s"),
+	Cards("2s", "2d", "2c", "3h", "3s"),
+	Cards("2s", "2d", "2c", "2h", "3s"),
+	Cards("6s", "5s", "4s", "3s", "2s"),
+	Cards("As", "Ks", "Qs", "Js", "Ts"),
+}
+
+func TestDeuceToSevenLowOrder(t *testing.T) {
+	hands := []*hand.Hand{}
+	for _, cards := range deuceToSevenOrder {
+		hands = append(hands, hand.New(cards, hand.DeuceToSevenLow))
+	}
+	for i := 1; i < len(hands); i++ {
+		if hands[i-1].Value() <= hands[i].Value() {
+			t.Fatalf("expected %v to beat %v", hands[i-1], hands[i])
+		}
+	}
+	if hands[0].Value() != hand.ValueCount(hand.DeuceToSevenLow) {
+		t.Fatalf("expected %v to be the best low", hands[0])
+	}
+	sorted := hand.Sort(hand.SortingLow, hand.DESC, hands[3], hands[0], hands[7])
+	if sorted[0] != hands[0] || sorted[1] != hands[3] || sorted[2] != hands[7] {
+		t.Fatalf("unexpected sort order %v", sorted)
+	}
+}
+
+var deuceToSevenTests = []testOptionsPairs{
+	{
+		Cards("7s", "5d", "4c", "3h", "2s", "Kd", "Kh"),
+		Cards("7s", "5d", "4c", "3h", "2s"),
+		[]func(*hand.Config){hand.DeuceToSevenLow},
+		hand.StdHighCard,
+		"seven-five low",
+	},
+	{
+		Cards("As", "5d", "4c", "3h", "2s"),
+		Cards("As", "5d", "4c", "3h", "2s"),
+		[]func(*hand.Config){hand.DeuceToSevenLow},
+		hand.StdHighCard,
+		"ace-five low",
+	},
+	{
+		Cards("6s", "5d", "4c", "3h", "2s", "2d", "9c"),
+		Cards("9c", "5d", "4c", "3h", "2s"),
+		[]func(*hand.Config){hand.DeuceToSevenLow},
+		hand.StdHighCard,
+		"nine-five low",
+	},
+}
+
+func TestDeuceToSevenLow(t *testing.T) {
+	for _, test := range deuceToSevenTests {
+		h := hand.New(test.cards, test.options...)
+		if h.Ranking() != test.ranking {
+			t.Fatalf("expected %v got %v", test.ranking, h.Ranking())
+		}
+		for i := 0; i < 5; i++ {
+			actual, expected := h.Cards()[i], test.arrangement[i]
+			if actual != expected {
+				t.Fatalf("expected %v got %v", expected, actual)
+			}
+		}
+		if test.description != h.Description() {
+			t.Fatalf("expected \"%v\" got \"%v\"", test.description, h.Description())
+		}
+	}
+}
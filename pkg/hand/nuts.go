@@ -0,0 +1,166 @@
+package hand
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/notnil/joker/util"
+)
+
+// A NutClass is a class of hands of equal value on a board.  Hand is the
+// hand made by the first of the Combos, the hole cards that make it.
+type NutClass struct {
+	Hand   *Hand
+	Combos []CardSet
+}
+
+// Nuts holds the classes of every hand that can be made on a board from
+// best to worst.
+type Nuts struct {
+	Board   []Card
+	Classes []NutClass
+	omaha   bool
+	options []func(*Config)
+}
+
+// NewNuts returns the classes of every hold'em hand on the board formed
+// with the given options by enumerating every two hole cards of the
+// unseen cards of the options' ranking scheme.  NewNuts panics if the
+// board isn't three to five cards.
+func NewNuts(board []Card, options ...func(*Config)) *Nuts {
+	return newNuts(board, 2, false, options)
+}
+
+// NewOmahaNuts is like NewNuts except that hands are formed from the
+// given number of hole cards with Omaha's rule of exactly two hole cards
+// and three board cards.  Enumerating five or more hole cards is slow.
+func NewOmahaNuts(board []Card, holeCards int, options ...func(*Config)) *Nuts {
+	if holeCards < 2 {
+		panic("hand: omaha requires at least two hole cards")
+	}
+	return newNuts(board, holeCards, true, options)
+}
+
+func newNuts(board []Card, holeCards int, omaha bool, options []func(*Config)) *Nuts {
+	if len(board) < 3 || len(board) > 5 {
+		panic("hand: nuts require a board of three to five cards")
+	}
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	n := &Nuts{
+		Board:   append([]Card{}, board...),
+		omaha:   omaha,
+		options: options,
+	}
+	known := NewCardSet(board...)
+	unseen := []Card{}
+	for _, card := range Scheme(c.gameType).Cards() {
+		if !known.Contains(card) && !card.IsJoker() {
+			unseen = append(unseen, card)
+		}
+	}
+	classes := map[int]int{}
+	hole := make([]Card, holeCards)
+	util.ForEachCombination(len(unseen), holeCards, func(idx []int) bool {
+		for i, j := range idx {
+			hole[i] = unseen[j]
+		}
+		h := n.hand(hole)
+		i, ok := classes[h.Value()]
+		if !ok {
+			i = len(n.Classes)
+			classes[h.Value()] = i
+			n.Classes = append(n.Classes, NutClass{Hand: h})
+		}
+		n.Classes[i].Combos = append(n.Classes[i].Combos, NewCardSet(hole...))
+		return true
+	})
+	sort.Slice(n.Classes, func(i, j int) bool {
+		return n.Classes[i].Hand.Value() > n.Classes[j].Hand.Value()
+	})
+	return n
+}
+
+func (n *Nuts) hand(hole []Card) *Hand {
+	if n.omaha {
+		return NewOmaha(hole, n.Board, n.options...)
+	}
+	return New(append(append([]Card{}, hole...), n.Board...), n.options...)
+}
+
+// Nuts returns the best class of hands on the board.
+func (n *Nuts) Nuts() NutClass {
+	return n.Classes[0]
+}
+
+// A RelativeRank is where a hand ranks among the hands opponents can hold
+// on a board.  Position is one for the nuts, two for the second nuts and
+// so on, and Better holds the classes that beat the hand from best to
+// worst.  Opponent hands that share cards with the hole cards aren't
+// counted.
+type RelativeRank struct {
+	Hand     *Hand
+	Position int
+	BeatenBy int
+	Better   []NutClass
+}
+
+// Rank returns the relative rank of the hole cards on the board.
+func (n *Nuts) Rank(hole []Card) *RelativeRank {
+	r := &RelativeRank{Hand: n.hand(hole), Position: 1}
+	held := NewCardSet(hole...)
+	for _, class := range n.Classes {
+		if class.Hand.Value() <= r.Hand.Value() {
+			break
+		}
+		better := NutClass{Hand: class.Hand}
+		for _, combo := range class.Combos {
+			if !combo.Overlaps(held) {
+				better.Combos = append(better.Combos, combo)
+			}
+		}
+		if len(better.Combos) == 0 {
+			continue
+		}
+		r.Position++
+		r.BeatenBy += len(better.Combos)
+		r.Better = append(r.Better, better)
+	}
+	return r
+}
+
+// IsNuts returns true if no opponent hand beats the hand.
+func (r *RelativeRank) IsNuts() bool {
+	return r.Position == 1
+}
+
+// String returns the rank in the format "3rd nuts, beaten by 42 combos"
+// or "the nuts".
+func (r *RelativeRank) String() string {
+	if r.IsNuts() {
+		return "the nuts"
+	}
+	combos := "combos"
+	if r.BeatenBy == 1 {
+		combos = "combo"
+	}
+	return fmt.Sprintf("%s nuts, beaten by %d %s", ordinal(r.Position), r.BeatenBy, combos)
+}
+
+// ordinal returns the number with its English ordinal suffix such as
+// "2nd".
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
@@ -0,0 +1,45 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestNuts(t *testing.T) {
+	board := Cards("As", "Ks", "Qs", "2d", "7c")
+	n := hand.NewNuts(board)
+	if nuts := n.Nuts(); nuts.Hand.Ranking() != hand.StdRoyalFlush || len(nuts.Combos) != 1 {
+		t.Fatalf("expected a single royal flush to be the nuts got %v", nuts.Hand)
+	}
+	if r := n.Rank(Cards("Js", "Ts")); !r.IsNuts() || r.String() != "the nuts" {
+		t.Fatalf("expected the nuts got %v", r)
+	}
+	// a royal flush, 44 flushes, and 15 straights beat a set of aces
+	r := n.Rank(Cards("Ah", "Ad"))
+	if r.String() != "47th nuts, beaten by 60 combos" || len(r.Better) != 46 {
+		t.Fatalf("expected 47th nuts, beaten by 60 combos got %v", r)
+	}
+	// holding the jack of spades blocks the royal flush
+	r = n.Rank(Cards("Js", "9s"))
+	if !r.IsNuts() {
+		t.Fatalf("expected the nuts got %v", r)
+	}
+}
+
+func TestOmahaNuts(t *testing.T) {
+	board := Cards("As", "Ks", "Qs", "2d", "7c")
+	n := hand.NewOmahaNuts(board, 4)
+	if nuts := n.Nuts(); nuts.Hand.Ranking() != hand.StdRoyalFlush {
+		t.Fatalf("expected a royal flush to be the nuts got %v", nuts.Hand)
+	}
+	// a single spade doesn't make a flush in omaha
+	r := n.Rank(Cards("Js", "Ah", "Ad", "3c"))
+	if r.Hand.Ranking() != hand.StdThreeOfAKind || r.IsNuts() {
+		t.Fatalf("expected a set of aces that isn't the nuts got %v", r)
+	}
+	if r := n.Rank(Cards("Js", "Ts", "9h", "9d")); !r.IsNuts() {
+		t.Fatalf("expected the nuts got %v", r)
+	}
+}
@@ -0,0 +1,101 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+type omahaTest struct {
+	hole        []hand.Card
+	board       []hand.Card
+	options     []func(*hand.Config)
+	ranking     hand.Ranking
+	description string
+}
+
+var omahaTests = []omahaTest{
+	{
+		Cards("Ts", "3c", "4d", "5h"),
+		Cards("As", "Ks", "Qs", "Js", "2h"),
+		nil,
+		hand.StdHighCard,
+		"high card ace high",
+	},
+	{
+		Cards("Ah", "Ac", "7d", "8d"),
+		Cards("As", "Kd", "Qd", "2d", "3h"),
+		nil,
+		hand.StdFlush,
+		"flush king high",
+	},
+	{
+		Cards("Ah", "Ac", "7d", "8c", "Kh"),
+		Cards("As", "Kd", "Qd", "2d", "3h"),
+		nil,
+		hand.StdThreeOfAKind,
+		"three of a kind aces",
+	},
+	{
+		Cards("Ah", "2c", "Kd", "Kh", "Qc", "Qh"),
+		Cards("3s", "4d", "5d", "Jd", "Jh"),
+		[]func(*hand.Config){hand.AceToFiveLow},
+		hand.StdHighCard,
+		"high card five high",
+	},
+	{
+		Cards("6s", "9d", "Kc", "Kh"),
+		Cards("Ts", "8d", "7d", "Ah", "Ac"),
+		[]func(*hand.Config){hand.ShortDeck},
+		hand.SDStraight,
+		"straight ten high",
+	},
+}
+
+func TestOmaha(t *testing.T) {
+	for _, test := range omahaTests {
+		h := hand.NewOmaha(test.hole, test.board, test.options...)
+		if h.Ranking() != test.ranking {
+			t.Fatalf("expected %v got %v", test.ranking, h.Ranking())
+		}
+		if test.description != h.Description() {
+			t.Fatalf("expected \"%v\" got \"%v\"", test.description, h.Description())
+		}
+		hole := hand.NewCardSet(test.hole...)
+		used := hand.NewCardSet(h.Cards()...).Intersect(hole)
+		if used.Count() != 2 {
+			t.Fatalf("expected %v to use exactly two hole cards", h)
+		}
+	}
+}
+
+func TestOmahaShort(t *testing.T) {
+	tests := []struct {
+		hole, board []hand.Card
+		description string
+		cards       int
+	}{
+		{Cards("As", "Ad", "Kc", "Qh"), nil, "pair of aces", 2},
+		{Cards("As", "Ks", "Qd", "Jd"), Cards("Ah", "7c"), "pair of aces", 4},
+		{Cards("As"), Cards("Kd", "Kh", "Kc", "2s"), "three of a kind kings", 4},
+	}
+	for _, test := range tests {
+		h := hand.NewOmaha(test.hole, test.board)
+		if h.Description() != test.description || len(h.Cards()) != test.cards {
+			t.Fatalf("expected %q of %d cards got %v", test.description, test.cards, h)
+		}
+	}
+	if h := hand.NewOmaha(nil, nil); h.Ranking() != hand.StdHighCard || len(h.Cards()) != 0 || h.Value() != 0 {
+		t.Fatalf("expected an empty high card hand got %v", h)
+	}
+	if h := hand.NewOmaha(nil, nil, hand.ShortDeck); h.Ranking() != hand.SDHighCard {
+		t.Fatalf("expected an empty short deck high card hand got %v", h)
+	}
+	if hilo := hand.NewOmahaHiLo(nil, nil); hilo.HasLow() || len(hilo.High.Cards()) != 0 {
+		t.Fatalf("expected an empty high hand and no low got %v", hilo)
+	}
+	if hilo := hand.NewOmahaHiLo(Cards("Ah", "2c", "3d", "Kh"), Cards("4s", "5s")); hilo.HasLow() || hilo.High.Description() != "high card ace high" {
+		t.Fatalf("expected a high card and no low got %v", hilo)
+	}
+}
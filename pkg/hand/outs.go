@@ -0,0 +1,327 @@
+package hand
+
+import (
+	"math/bits"
+)
+
+// A DrawType is a kind of drawing hand.
+type DrawType int
+
+const (
+	// FlushDraw is four cards of a suit needing one more.
+	FlushDraw DrawType = iota + 1
+
+	// OpenEndedStraightDraw is four consecutive ranks that make a
+	// straight with a card of the rank at either end.
+	OpenEndedStraightDraw
+
+	// Gutshot is a straight draw that only one rank completes.
+	Gutshot
+
+	// DoubleGutter is a straight draw that two ranks complete without
+	// four consecutive ranks, such as 9-7-6-5-3.
+	DoubleGutter
+
+	// BackdoorFlushDraw is three cards of a suit on the flop needing both
+	// the turn and the river.
+	BackdoorFlushDraw
+
+	// BackdoorStraightDraw is three ranks of a straight on the flop
+	// needing both the turn and the river.
+	BackdoorStraightDraw
+)
+
+var drawTypeNames = []string{"", "flush draw", "open-ended straight draw", "gutshot",
+	"double gutter", "backdoor flush draw", "backdoor straight draw"}
+
+// String returns the name of the draw such as "flush draw".
+func (d DrawType) String() string {
+	return drawTypeNames[d]
+}
+
+// An Out is an unseen card that improves a hand to a higher Ranking.
+// Nuts is true if no other hole cards would beat the improved hand.
+type Out struct {
+	Card    Card
+	Ranking Ranking
+	Nuts    bool
+}
+
+// A Draw is a drawing hand.  Outs holds the cards that complete the draw
+// on the next card, which backdoor draws don't have.  OneCard is the
+// probability that the next card completes the draw and TwoCard is the
+// probability that it's completed by the river.
+type Draw struct {
+	Type    DrawType
+	Outs    []Card
+	OneCard float64
+	TwoCard float64
+}
+
+// OutsAnalysis lists the outs and draws of hole cards on a flop or turn
+// board.  OneCard is the probability that the next card is an out and
+// TwoCard is the probability of improving to a higher Ranking by the
+// river.  On the turn both probabilities are for the river.
+type OutsAnalysis struct {
+	Hand    *Hand
+	Outs    []Out
+	Draws   []Draw
+	OneCard float64
+	TwoCard float64
+}
+
+// AnalyzeOuts returns the outs and draws of the hole cards on the board
+// formed with the given options.  Unseen cards are the cards of the
+// options' ranking scheme not in the hole or on the board.  A card is an
+// out if it improves the hand to a higher Ranking than both the current
+// hand and the board with the card, so cards that improve every player
+// aren't counted.  AnalyzeOuts panics if the board isn't a flop or turn.
+func AnalyzeOuts(hole, board []Card, options ...func(*Config)) *OutsAnalysis {
+	if len(board) < 3 || len(board) > 4 {
+		panic("hand: outs require a board of three or four cards")
+	}
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	o := &outsAnalyzer{
+		hole:      hole,
+		board:     board,
+		options:   options,
+		straights: straightMasks(c.gameType),
+	}
+	o.current = New(o.with(), options...)
+	known := NewCardSet(hole...).Union(NewCardSet(board...))
+	for _, card := range Scheme(c.gameType).Cards() {
+		if !known.Contains(card) && !card.IsJoker() {
+			o.unseen = append(o.unseen, card)
+		}
+	}
+
+	a := &OutsAnalysis{Hand: o.current}
+	for _, card := range o.unseen {
+		h, ok := o.improves(card)
+		if !ok {
+			continue
+		}
+		a.Outs = append(a.Outs, Out{Card: card, Ranking: h.Ranking(), Nuts: o.isNuts(h, card)})
+	}
+	a.OneCard = float64(len(a.Outs)) / float64(len(o.unseen))
+	a.TwoCard = a.OneCard
+	if len(board) == 3 {
+		a.TwoCard = o.probability(func(extra ...Card) bool {
+			_, ok := o.improves(extra...)
+			return ok
+		}, 2)
+	}
+	a.Draws = o.draws()
+	return a
+}
+
+type outsAnalyzer struct {
+	hole      []Card
+	board     []Card
+	options   []func(*Config)
+	straights []uint16
+	current   *Hand
+	unseen    []Card
+}
+
+// with returns the hole cards and board with the extra cards.
+func (o *outsAnalyzer) with(extra ...Card) []Card {
+	cards := append(append([]Card{}, o.hole...), o.board...)
+	return append(cards, extra...)
+}
+
+// improves returns the hand with the extra cards if it's of a higher
+// Ranking than the current hand and the board with the extra cards.
+func (o *outsAnalyzer) improves(extra ...Card) (*Hand, bool) {
+	h := New(o.with(extra...), o.options...)
+	if h.Ranking() <= o.current.Ranking() {
+		return h, false
+	}
+	boardHand := New(append(append([]Card{}, o.board...), extra...), o.options...)
+	return h, h.Ranking() > boardHand.Ranking()
+}
+
+// isNuts returns true if no hole cards from the unseen cards beat the
+// hand on the board with the out.
+func (o *outsAnalyzer) isNuts(h *Hand, out Card) bool {
+	board := append(append([]Card{}, o.board...), out)
+	for i, c0 := range o.unseen {
+		for _, c1 := range o.unseen[i+1:] {
+			if c0 == out || c1 == out {
+				continue
+			}
+			if New(append([]Card{c0, c1}, board...), o.options...).Value() > h.Value() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// probability returns the probability that the next n unseen cards
+// satisfy f.
+func (o *outsAnalyzer) probability(f func(extra ...Card) bool, n int) float64 {
+	hits, total := 0, 0
+	for i, c0 := range o.unseen {
+		if n == 1 {
+			total++
+			if f(c0) {
+				hits++
+			}
+			continue
+		}
+		for _, c1 := range o.unseen[i+1:] {
+			total++
+			if f(c0, c1) {
+				hits++
+			}
+		}
+	}
+	return float64(hits) / float64(total)
+}
+
+// draws classifies the flush and straight draws of the hand.
+func (o *outsAnalyzer) draws() []Draw {
+	draws := []Draw{}
+	for _, suit := range allSuits() {
+		held, n := false, 0
+		for _, c := range o.with() {
+			if c.Suit() == suit {
+				n++
+				held = held || contains(o.hole, c)
+			}
+		}
+		completes := func(extra ...Card) bool {
+			m := n
+			for _, c := range extra {
+				if c.Suit() == suit {
+					m++
+				}
+			}
+			return m >= 5
+		}
+		switch {
+		case !held || n >= 5:
+		case n == 4:
+			draws = append(draws, o.draw(FlushDraw, completes))
+		case n == 3 && len(o.board) == 3:
+			draws = append(draws, o.draw(BackdoorFlushDraw, completes))
+		}
+	}
+
+	ranks, boardRanks := rankMask(o.with()), rankMask(o.board)
+	if o.straight(ranks) {
+		return draws
+	}
+	completes := func(extra ...Card) bool {
+		return o.straight(ranks | rankMask(extra))
+	}
+	// completing ranks and the ranks of the straight they complete
+	completing := map[Rank][]uint16{}
+	backdoor := false
+	for _, s := range o.straights {
+		missing := s &^ ranks
+		if s&^(boardRanks|missing) == 0 {
+			// the hole cards don't play
+			continue
+		}
+		switch bits.OnesCount16(missing) {
+		case 1:
+			r := Rank(bits.TrailingZeros16(missing))
+			completing[r] = append(completing[r], s&^missing)
+		case 2:
+			backdoor = true
+		}
+	}
+	switch {
+	case len(completing) >= 2 && openEnded(completing):
+		draws = append(draws, o.draw(OpenEndedStraightDraw, completes))
+	case len(completing) >= 2:
+		draws = append(draws, o.draw(DoubleGutter, completes))
+	case len(completing) == 1:
+		draws = append(draws, o.draw(Gutshot, completes))
+	case backdoor && len(o.board) == 3:
+		draws = append(draws, o.draw(BackdoorStraightDraw, completes))
+	}
+	return draws
+}
+
+// draw returns the draw completed by the extra cards that satisfy
+// completes.
+func (o *outsAnalyzer) draw(t DrawType, completes func(extra ...Card) bool) Draw {
+	d := Draw{Type: t}
+	if t != BackdoorFlushDraw && t != BackdoorStraightDraw {
+		for _, c := range o.unseen {
+			if completes(c) {
+				d.Outs = append(d.Outs, c)
+			}
+		}
+	}
+	d.OneCard = o.probability(completes, 1)
+	d.TwoCard = d.OneCard
+	if len(o.board) == 3 {
+		d.TwoCard = o.probability(completes, 2)
+	}
+	return d
+}
+
+// straight returns true if the ranks contain a straight.
+func (o *outsAnalyzer) straight(ranks uint16) bool {
+	for _, s := range o.straights {
+		if ranks&s == s {
+			return true
+		}
+	}
+	return false
+}
+
+// openEnded returns true if two completing ranks complete straights with
+// the same four ranks, which are then consecutive.
+func openEnded(completing map[Rank][]uint16) bool {
+	seen := map[uint16]Rank{}
+	for r, sets := range completing {
+		for _, s := range sets {
+			if other, ok := seen[s]; ok && other != r {
+				return true
+			}
+			seen[s] = r
+		}
+	}
+	return false
+}
+
+// straightMasks returns the rank masks of the straights of the game
+// type's ranking scheme.
+func straightMasks(g GameType) []uint16 {
+	masks := []uint16{}
+	for _, straight := range Scheme(g).Straights() {
+		var mask uint16
+		for _, r := range straight {
+			mask |= 1 << uint(r)
+		}
+		masks = append(masks, mask)
+	}
+	return masks
+}
+
+func rankMask(cards []Card) uint16 {
+	var mask uint16
+	for _, c := range cards {
+		if !c.IsJoker() {
+			mask |= 1 << uint(c.Rank())
+		}
+	}
+	return mask
+}
+
+func contains(cards []Card, card Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package hand_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+var drawTests = []struct {
+	hole    []hand.Card
+	board   []hand.Card
+	options []func(*hand.Config)
+	draws   []hand.DrawType
+	outs    []int
+}{
+	{Cards("Ah", "Kh"), Cards("Qh", "7h", "2c"), nil, []hand.DrawType{hand.FlushDraw, hand.BackdoorStraightDraw}, []int{9, 0}},
+	{Cards("8s", "9d"), Cards("7c", "6h", "Kd"), nil, []hand.DrawType{hand.OpenEndedStraightDraw}, []int{8}},
+	{Cards("9s", "7d"), Cards("5c", "3h", "6d"), nil, []hand.DrawType{hand.DoubleGutter}, []int{8}},
+	{Cards("Ah", "8h"), Cards("9h", "7c", "Kd"), nil, []hand.DrawType{hand.BackdoorFlushDraw, hand.BackdoorStraightDraw}, []int{0, 0}},
+	{Cards("As", "7d"), Cards("8c", "9h", "Kd"), []func(*hand.Config){hand.ShortDeck}, []hand.DrawType{hand.Gutshot}, []int{4}},
+	{Cards("Qc", "Qd"), Cards("Qh", "7h", "2c", "7c"), nil, []hand.DrawType{}, []int{}},
+}
+
+func TestDraws(t *testing.T) {
+	for _, test := range drawTests {
+		a := hand.AnalyzeOuts(test.hole, test.board, test.options...)
+		if len(a.Draws) != len(test.draws) {
+			t.Fatalf("%v %v: expected draws %v got %+v", test.hole, test.board, test.draws, a.Draws)
+		}
+		for i, d := range a.Draws {
+			if d.Type != test.draws[i] || len(d.Outs) != test.outs[i] {
+				t.Fatalf("%v %v: expected %v with %d outs got %v with %v", test.hole, test.board, test.draws[i], test.outs[i], d.Type, d.Outs)
+			}
+		}
+	}
+}
+
+func TestOuts(t *testing.T) {
+	a := hand.AnalyzeOuts(Cards("Ah", "Kh"), Cards("Qh", "7h", "2c"))
+	if len(a.Outs) != 15 {
+		t.Fatalf("expected 15 outs got %d %+v", len(a.Outs), a.Outs)
+	}
+	nuts := map[hand.Card]bool{hand.ThreeHearts: true, hand.TwoHearts: false, hand.AceSpades: false}
+	for _, out := range a.Outs {
+		if expected, ok := nuts[out.Card]; ok && out.Nuts != expected {
+			t.Fatalf("expected %v nuts to be %v got %+v", out.Card, expected, out)
+		}
+		if out.Card == hand.ThreeHearts && out.Ranking != hand.StdFlush {
+			t.Fatalf("expected %v to make a flush got %v", out.Card, out.Ranking)
+		}
+	}
+	if math.Abs(a.OneCard-15.0/47) > 1e-9 {
+		t.Fatalf("expected one card probability %v got %v", 15.0/47, a.OneCard)
+	}
+	flush := a.Draws[0]
+	if math.Abs(flush.OneCard-9.0/47) > 1e-9 || math.Abs(flush.TwoCard-378.0/1081) > 1e-9 {
+		t.Fatalf("expected flush draw probabilities %v and %v got %+v", 9.0/47, 378.0/1081, flush)
+	}
+}
+
+func TestOutsTurn(t *testing.T) {
+	a := hand.AnalyzeOuts(Cards("8s", "9d"), Cards("7c", "6h", "Kd", "2s"))
+	if len(a.Outs) != 14 || a.OneCard != a.TwoCard {
+		t.Fatalf("expected 14 outs with the same probabilities got %d %v %v", len(a.Outs), a.OneCard, a.TwoCard)
+	}
+}
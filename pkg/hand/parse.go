@@ -0,0 +1,257 @@
+package hand
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A ParseError describes why text couldn't be parsed as cards.  Pos is
+// the byte offset in Text where the problem was found.
+type ParseError struct {
+	Text string
+	Pos  int
+	Msg  string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("hand: %s at position %d of %q", e.Msg, e.Pos, e.Text)
+}
+
+var (
+	asciiSuits = map[rune]Suit{
+		's': Spades, 'h': Hearts, 'd': Diamonds, 'c': Clubs,
+		'♠': Spades, '♥': Hearts, '♦': Diamonds, '♣': Clubs,
+		'♤': Spades, '♡': Hearts, '♢': Diamonds, '♧': Clubs,
+	}
+	asciiJokers = []string{"BJ", "RJ"}
+)
+
+// symbolBase is the code point of the ace of spades.  Unicode playing
+// cards are in a block of sixteen code points for each suit with the
+// knights, which aren't dealt, between the jacks and queens.
+const symbolBase = 0x1F0A1
+
+// symbolSuits orders the suits as they are ordered in the playing card
+// block of Unicode.
+var symbolSuits = []Suit{Spades, Hearts, Diamonds, Clubs}
+
+// ParseCard parses a single card.  See ParseCards for the accepted
+// formats.
+func ParseCard(s string) (Card, error) {
+	p := &cardParser{text: s}
+	p.skip()
+	if p.done() {
+		return 0, p.errorf("no card")
+	}
+	c, err := p.card()
+	if err != nil {
+		return 0, err
+	}
+	p.skip()
+	if !p.done() {
+		return 0, p.errorf("unexpected text %q after card", p.text[p.pos:])
+	}
+	return c, nil
+}
+
+// ParseRank parses a single rank written 2-9, T, 10, J, Q, K, or A in
+// either case.
+func ParseRank(s string) (Rank, error) {
+	p := &cardParser{text: s}
+	p.skip()
+	if p.done() {
+		return 0, p.errorf("no rank")
+	}
+	r, err := p.rank()
+	if err != nil {
+		return 0, err
+	}
+	p.skip()
+	if !p.done() {
+		return 0, p.errorf("unexpected text %q after rank", p.text[p.pos:])
+	}
+	return r, nil
+}
+
+// MustParseCard is like ParseCard but panics if the card can't be
+// parsed.
+func MustParseCard(s string) Card {
+	c, err := ParseCard(s)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ParseCards parses a list of cards that may be separated by spaces or
+// commas or concatenated such as "Qs7d2c".  Each card may be written in
+// ASCII with a rank of 2-9, T, 10, J, Q, K, or A and a suit of s, h, d,
+// or c in either case ("Ah", "10h", "ah"), with a unicode suit ("A♥"),
+// or as a Unicode playing card ("🂱").  Jokers are written "🃏" and "🂿"
+// or "BJ" and "RJ".  An error is returned if a card is invalid or given
+// more than once.
+func ParseCards(s string) ([]Card, error) {
+	p := &cardParser{text: s}
+	cards := []Card{}
+	var seen CardSet
+	for p.skip(); !p.done(); p.skip() {
+		pos := p.pos
+		c, err := p.card()
+		if err != nil {
+			return nil, err
+		}
+		if seen.Contains(c) {
+			return nil, &ParseError{Text: s, Pos: pos, Msg: "duplicate card " + c.String()}
+		}
+		seen.Add(c)
+		cards = append(cards, c)
+	}
+	return cards, nil
+}
+
+// MustParseCards is like ParseCards but panics if the cards can't be
+// parsed.
+func MustParseCards(s string) []Card {
+	cards, err := ParseCards(s)
+	if err != nil {
+		panic(err)
+	}
+	return cards
+}
+
+type cardParser struct {
+	text string
+	pos  int
+}
+
+func (p *cardParser) done() bool {
+	return p.pos >= len(p.text)
+}
+
+func (p *cardParser) peek() rune {
+	r, _ := utf8.DecodeRuneInString(p.text[p.pos:])
+	return r
+}
+
+func (p *cardParser) next() rune {
+	r, n := utf8.DecodeRuneInString(p.text[p.pos:])
+	p.pos += n
+	return r
+}
+
+// skip moves past spaces and commas between cards.
+func (p *cardParser) skip() {
+	for !p.done() {
+		r := p.peek()
+		if !unicode.IsSpace(r) && r != ',' {
+			return
+		}
+		p.next()
+	}
+}
+
+func (p *cardParser) errorf(format string, args ...interface{}) error {
+	return &ParseError{Text: p.text, Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// card parses the card at the current position.
+func (p *cardParser) card() (Card, error) {
+	for i, j := range jokersStr {
+		if strings.HasPrefix(p.text[p.pos:], j) {
+			p.pos += len(j)
+			return BlackJoker + Card(i), nil
+		}
+	}
+	for i, j := range asciiJokers {
+		if len(p.text)-p.pos >= len(j) && strings.EqualFold(p.text[p.pos:p.pos+len(j)], j) {
+			p.pos += len(j)
+			return BlackJoker + Card(i), nil
+		}
+	}
+	if r := p.peek(); r >= symbolBase && r < symbolBase+0x40 {
+		start := p.pos
+		p.next()
+		return p.symbol(r, start)
+	}
+	rank, err := p.rank()
+	if err != nil {
+		return 0, err
+	}
+	if p.done() {
+		return 0, p.errorf("missing suit")
+	}
+	suitPos := p.pos
+	suit, ok := asciiSuits[unicode.ToLower(p.next())]
+	if !ok {
+		p.pos = suitPos
+		return 0, p.errorf("invalid suit %q", p.peek())
+	}
+	return getCard(rank, suit), nil
+}
+
+// rank parses the rank at the current position.
+func (p *cardParser) rank() (Rank, error) {
+	start := p.pos
+	r := p.next()
+	switch i := strings.IndexRune(ranksStr, unicode.ToUpper(r)); {
+	case r == '1' && p.peek() == '0':
+		p.next()
+		return Ten, nil
+	case i >= 0 && r < utf8.RuneSelf:
+		return Rank(i), nil
+	}
+	p.pos = start
+	return 0, p.errorf("invalid rank %q", r)
+}
+
+// symbol returns the card of the Unicode playing card r.
+func (p *cardParser) symbol(r rune, start int) (Card, error) {
+	offset := int(r - symbolBase + 1)
+	suit, n := offset/16, offset%16
+	switch {
+	case n == 1:
+		return getCard(Ace, symbolSuits[suit]), nil
+	case n >= 2 && n <= 10:
+		return getCard(Rank(n-2), symbolSuits[suit]), nil
+	case n == 11:
+		return getCard(Jack, symbolSuits[suit]), nil
+	case n == 13 || n == 14:
+		return getCard(Rank(n-3), symbolSuits[suit]), nil
+	}
+	p.pos = start
+	return 0, p.errorf("invalid playing card %q", r)
+}
+
+// ASCII returns the card in the format "Ah", or "BJ" and "RJ" for the
+// black and red jokers.
+func (c Card) ASCII() string {
+	if c.IsJoker() {
+		return asciiJokers[c-BlackJoker]
+	}
+	return c.Rank().String() + "shdc"[c.Suit():c.Suit()+1]
+}
+
+// Symbol returns the card as a Unicode playing card such as "🂡" for the
+// ace of spades.  Jokers are "🃏" and "🂿".
+func (c Card) Symbol() string {
+	if c.IsJoker() {
+		return jokersStr[c-BlackJoker]
+	}
+	n := int(c.Rank()) + 2
+	switch c.Rank() {
+	case Ace:
+		n = 1
+	case Queen, King:
+		n++
+	}
+	return string(rune(symbolBase - 1 + 16*int(c.Suit()) + n))
+}
+
+// Name returns the full English name of the card such as "ace of
+// spades".  Locale.CardName names cards in other languages.
+func (c Card) Name() string {
+	return English.CardName(c)
+}
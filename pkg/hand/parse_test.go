@@ -0,0 +1,128 @@
+package hand_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		text  string
+		cards []hand.Card
+		err   string
+	}{
+		{"Ah", Cards("Ah"), ""},
+		{"ah", Cards("Ah"), ""},
+		{"10h", Cards("Th"), ""},
+		{"tH", Cards("Th"), ""},
+		{"A♥", Cards("Ah"), ""},
+		{"Q♤", Cards("Qs"), ""},
+		{"🂡🂱🃁🃑", Cards("As", "Ah", "Ad", "Ac"), ""},
+		{"🂫🂭🂮🂪", Cards("Js", "Qs", "Ks", "Ts"), ""},
+		{"Qs7d2c", Cards("Qs", "7d", "2c"), ""},
+		{"Qs 7d, 2c", Cards("Qs", "7d", "2c"), ""},
+		{"10c10d", Cards("Tc", "Td"), ""},
+		{"🃏 rj", []hand.Card{hand.BlackJoker, hand.RedJoker}, ""},
+		{"  ", []hand.Card{}, ""},
+		{"QsQs", nil, `hand: duplicate card Q♠ at position 2 of "QsQs"`},
+		{"Qs1d", nil, `hand: invalid rank '1' at position 2 of "Qs1d"`},
+		{"Qx", nil, `hand: invalid suit 'x' at position 1 of "Qx"`},
+		{"Q", nil, `hand: missing suit at position 1 of "Q"`},
+		{"🂬", nil, `hand: invalid playing card '🂬' at position 0 of "🂬"`},
+	}
+	for _, test := range tests {
+		cards, err := hand.ParseCards(test.text)
+		if test.err != "" {
+			if err == nil || err.Error() != test.err {
+				t.Fatalf("expected error %q parsing %q got %v", test.err, test.text, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parsing %q: %v", test.text, err)
+		}
+		if len(cards) != len(test.cards) {
+			t.Fatalf("expected %v got %v", test.cards, cards)
+		}
+		for i := range cards {
+			if cards[i] != test.cards[i] {
+				t.Fatalf("expected %v got %v", test.cards, cards)
+			}
+		}
+	}
+}
+
+func TestParseCard(t *testing.T) {
+	if c, err := hand.ParseCard(" Kd "); err != nil || c != hand.KingDiamonds {
+		t.Fatalf("expected K♦ got %v %v", c, err)
+	}
+	for _, s := range []string{"", "KdKh", "K♦ 2c"} {
+		_, err := hand.ParseCard(s)
+		perr := &hand.ParseError{}
+		if !errors.As(err, &perr) {
+			t.Fatalf("expected a parse error for %q got %v", s, err)
+		}
+	}
+}
+
+func TestParseRank(t *testing.T) {
+	for s, r := range map[string]hand.Rank{"A": hand.Ace, "t": hand.Ten, "10": hand.Ten, " 7 ": hand.Seven} {
+		if p, err := hand.ParseRank(s); err != nil || p != r {
+			t.Fatalf("expected %q to parse as %v got %v %v", s, r, p, err)
+		}
+	}
+	for _, s := range []string{"", "1", "As", "X"} {
+		_, err := hand.ParseRank(s)
+		perr := &hand.ParseError{}
+		if !errors.As(err, &perr) {
+			t.Fatalf("expected a parse error for %q got %v", s, err)
+		}
+	}
+}
+
+func TestCardFormats(t *testing.T) {
+	for _, c := range append(hand.StandardCards(), hand.Jokers(2)...) {
+		for _, s := range []string{c.String(), c.ASCII(), c.Symbol()} {
+			p, err := hand.ParseCard(s)
+			if err != nil || p != c {
+				t.Fatalf("expected %q to parse as %v got %v %v", s, c, p, err)
+			}
+		}
+	}
+	tests := []struct {
+		card                hand.Card
+		ascii, symbol, name string
+	}{
+		{hand.AceSpades, "As", "🂡", "ace of spades"},
+		{hand.QueenHearts, "Qh", "🂽", "queen of hearts"},
+		{hand.TenClubs, "Tc", "🃚", "ten of clubs"},
+		{hand.BlackJoker, "BJ", "🃏", "black joker"},
+	}
+	for _, test := range tests {
+		if test.card.ASCII() != test.ascii || test.card.Symbol() != test.symbol || test.card.Name() != test.name {
+			t.Fatalf("expected %q %q %q got %q %q %q", test.ascii, test.symbol, test.name,
+				test.card.ASCII(), test.card.Symbol(), test.card.Name())
+		}
+	}
+}
+
+func TestDeckText(t *testing.T) {
+	deck := &hand.Deck{Cards: Cards("As", "Td", "2c")}
+	text, err := deck.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := &hand.Deck{}
+	if err := cp.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if cp.String() != deck.String() {
+		t.Fatalf("expected %v got %v", deck, cp)
+	}
+	if err := cp.UnmarshalText([]byte("As,Zd")); err == nil {
+		t.Fatal("expected an error for an invalid card")
+	}
+}
@@ -1,28 +1,54 @@
 package hand
 
+//go:generate stringer -type=Ranking -trimprefix=Std -output=ranking_string.go ranking.go ranking_standard.go badugi.go
+
 // A Ranking is one of the ten possible hand rankings that determine the
 // value of a hand.  Hand rankings are composed of different arrangments of
-// pairs, straights, and flushes.
+// pairs, straights, and flushes.  String returns the name of the standard
+// or badugi ranking of the same value, such as "FullHouse" for
+// StdFullHouse and "BadugiFourCard" for BadugiFourCard.
 type Ranking int
 
-type validFunc func([]Card, Config) bool
-type descFunc func([]Card) string
+// A ValidFunc returns true if the formed cards are a hand of a ranking.
+// Cards are formed starting with the most paired ranks and with straights
+// arranged in the order given by the ranking scheme.
+type ValidFunc func(cards []Card, c Config) bool
+
+// A DescFunc returns the description of formed cards, for example "pair
+// of kings".  The description is used as it is in every locale.
+type DescFunc func(cards []Card) string
+
+// A DescriptionFunc returns the structured description of formed cards,
+// for example a DescPair of kings that renders as "pair of kings" in
+// English.  The Ranking of the description is set by the caller.
+type DescriptionFunc func(cards []Card) Description
+
+// A RankingRule recognizes and describes the hands of a Ranking.
+type RankingRule struct {
+	Ranking  Ranking
+	Valid    ValidFunc
+	Describe DescriptionFunc
+}
 
-type ranking struct {
-	r     Ranking
-	vFunc validFunc
-	dFunc descFunc
+// NewRanking returns a RankingRule for the ranking that describes hands
+// with the text returned by dFunc.
+func NewRanking(r Ranking, vFunc ValidFunc, dFunc DescFunc) RankingRule {
+	return NewDescribedRanking(r, vFunc, DescribeText(dFunc))
 }
 
-func NewRanking(r Ranking, vFunc validFunc, dFunc descFunc) ranking {
-	return ranking{
-		r:     r,
-		vFunc: vFunc,
-		dFunc: dFunc,
+// NewDescribedRanking returns a RankingRule for the ranking that
+// describes hands with structured descriptions, which can be rendered in
+// any language.
+func NewDescribedRanking(r Ranking, vFunc ValidFunc, dFunc DescriptionFunc) RankingRule {
+	return RankingRule{
+		Ranking:  r,
+		Valid:    vFunc,
+		Describe: dFunc,
 	}
 }
 
-func hasFlush(cards []Card) bool {
+// HasFlush returns true if there are five cards that share the same suit.
+func HasFlush(cards []Card) bool {
 	if len(cards) != 5 {
 		return false
 	}
@@ -34,29 +60,37 @@ func hasFlush(cards []Card) bool {
 	return has
 }
 
-func hasStraight(cards []Card) bool {
+// HasStraight returns true if the ranks of the formed cards are in the
+// order of one of the straights of the ranking scheme of the
+// configuration's game type.
+func HasStraight(cards []Card, c Config) bool {
 	if len(cards) != 5 {
 		return false
 	}
-	lastIndex := cards[0].Rank()
-	straight := true
-	for i := 1; i < 5; i++ {
-		index := cards[i].Rank()
-		straight = straight && (lastIndex == index+1)
-		lastIndex = index
+	for _, straight := range Scheme(c.gameType).Straights() {
+		if isStraight(cards, straight) {
+			return true
+		}
 	}
-	return straight || hasLowStraight(cards)
+	return false
 }
 
-func hasLowStraight(cards []Card) bool {
-	return cards[0].Rank() == Five &&
-		cards[1].Rank() == Four &&
-		cards[2].Rank() == Three &&
-		cards[3].Rank() == Two &&
-		cards[4].Rank() == Ace
+func isStraight(cards []Card, straight []Rank) bool {
+	if len(straight) != len(cards) {
+		return false
+	}
+	for i, r := range straight {
+		if cards[i].Rank() != r {
+			return false
+		}
+	}
+	return true
 }
 
-func hasPairs(cards []Card, pairNums []int) bool {
+// HasPairs returns true if the number of cards sharing the rank of each
+// formed card matches pairNums, for example []int{3, 3, 3, 2, 2} for a
+// full house.
+func HasPairs(cards []Card, pairNums []int) bool {
 	for i := 0; i < 5; i++ {
 		num := pairNums[i]
 		if i >= len(cards) {
@@ -69,18 +103,3 @@ func hasPairs(cards []Card, pairNums []int) bool {
 	}
 	return true
 }
-
-func getRankingsByType(gameType GameType) []ranking {
-	switch gameType {
-	case GameTypeShortDeck:
-		return []ranking{sdHighCard, sdPair, sdTwoPair, sdThreeOfAKind,
-			sdStraight, sdFlush, sdFullHouse, sdFourOfAKind, sdStraightFlush, sdRoyalFlush}
-	case GameTypeStandard:
-		return []ranking{stdHighCard, stdPair, stdTwoPair, stdThreeOfAKind,
-			stdStraight, stdFlush, stdFullHouse, stdFourOfAKind, stdStraightFlush, stdRoyalFlush}
-	default:
-		return []ranking{stdHighCard, stdPair, stdTwoPair, stdThreeOfAKind,
-			stdStraight, stdFlush, stdFullHouse, stdFourOfAKind, stdStraightFlush, stdRoyalFlush}
-	}
-
-}
@@ -1,9 +1,5 @@
 package hand
 
-import (
-	"fmt"
-)
-
 const (
 	// SDHighCard represents a hand composed of no pairs, straights, or flushes.
 	// Ex: A♠ K♠ J♣ 7♥ 5♦
@@ -46,15 +42,20 @@ const (
 	// of the same suit.
 	// Ex: A♥ K♥ Q♥ J♥ T♥
 	SDRoyalFlush
+
+	// SDFiveOfAKind represents a hand composed of five cards of the same rank,
+	// which requires wild cards.
+	// Ex: A♠ A♣ A♦ A♥ 🃏
+	SDFiveOfAKind
 )
 
 var (
-	sdHighCard = NewRanking(
+	sdHighCard = NewDescribedRanking(
 		SDHighCard,
 		func(cards []Card, c Config) bool {
-			flush := hasFlush(cards)
-			straight := hasStraightInShortDeck(cards)
-			pairs := hasPairs(cards, []int{1, 1, 1, 1, 1})
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
+			pairs := HasPairs(cards, []int{1, 1, 1, 1, 1})
 			if !c.ignoreStraights {
 				pairs = pairs && !straight
 			}
@@ -63,142 +64,121 @@ var (
 			}
 			return pairs
 		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("high card %v high", r.singularName())
-		},
+		DescribeAs(DescHighCard, 0),
 	)
 
-	sdPair = NewRanking(
+	sdPair = NewDescribedRanking(
 		SDPair,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{2, 2, 1, 1, 1})
-		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("pair of %v", r.pluralName())
+			return HasPairs(cards, []int{2, 2, 1, 1, 1})
 		},
+		DescribeAs(DescPair, 0),
 	)
 
-	sdTwoPair = NewRanking(
+	sdTwoPair = NewDescribedRanking(
 		SDTwoPair,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{2, 2, 2, 2, 1})
-		},
-		func(cards []Card) string {
-			r1 := cards[0].Rank()
-			r2 := cards[2].Rank()
-			return fmt.Sprintf("two pair %v and %v", r1.pluralName(), r2.pluralName())
+			return HasPairs(cards, []int{2, 2, 2, 2, 1})
 		},
+		DescribeAs(DescTwoPair, 0, 2),
 	)
 
-	sdThreeOfAKind = NewRanking(
+	sdThreeOfAKind = NewDescribedRanking(
 		SDThreeOfAKind,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{3, 3, 3, 1, 1})
-		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("three of a kind %v", r.pluralName())
+			return HasPairs(cards, []int{3, 3, 3, 1, 1})
 		},
+		DescribeAs(DescThreeOfAKind, 0),
 	)
 
-	sdStraight = NewRanking(
+	sdStraight = NewDescribedRanking(
 		SDStraight,
 		func(cards []Card, c Config) bool {
 			if c.ignoreStraights {
 				return false
 			}
-			flush := hasFlush(cards)
-			straight := hasStraightInShortDeck(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return !flush && straight
 		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("straight %v high", r.singularName())
-		},
+		DescribeAs(DescStraight, 0),
 	)
 
-	sdFlush = NewRanking(
+	sdFlush = NewDescribedRanking(
 		SDFlush,
 		func(cards []Card, c Config) bool {
 			if c.ignoreFlushes {
 				return false
 			}
 
-			flush := hasFlush(cards)
-			straight := hasStraightInShortDeck(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return flush && !straight
 		},
-		func(cards []Card) string {
-			r1 := cards[0].Rank()
-			return fmt.Sprintf("flush %v high", r1.singularName())
-		},
+		DescribeAs(DescFlush, 0),
 	)
 
-	sdFullHouse = NewRanking(
+	sdFullHouse = NewDescribedRanking(
 		SDFullHouse,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{3, 3, 3, 2, 2})
-		},
-		func(cards []Card) string {
-			r1 := cards[0].Rank()
-			r2 := cards[3].Rank()
-			return fmt.Sprintf("full house %v full of %v", r1.pluralName(), r2.pluralName())
+			return HasPairs(cards, []int{3, 3, 3, 2, 2})
 		},
+		DescribeAs(DescFullHouse, 0, 3),
 	)
 
-	sdFourOfAKind = NewRanking(
+	sdFourOfAKind = NewDescribedRanking(
 		SDFourOfAKind,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{4, 4, 4, 4, 1})
-		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("four of a kind %v", r.pluralName())
+			return HasPairs(cards, []int{4, 4, 4, 4, 1})
 		},
+		DescribeAs(DescFourOfAKind, 0),
 	)
 
-	sdStraightFlush = NewRanking(
+	sdStraightFlush = NewDescribedRanking(
 		SDStraightFlush,
 		func(cards []Card, c Config) bool {
 			if c.ignoreStraights || c.ignoreFlushes {
 				return false
 			}
-			flush := hasFlush(cards)
-			straight := hasStraightInShortDeck(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return cards[0].Rank() != Ace && flush && straight
 		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("straight flush %v high", r.singularName())
-		},
+		DescribeAs(DescStraightFlush, 0),
 	)
 
-	sdRoyalFlush = NewRanking(
+	sdRoyalFlush = NewDescribedRanking(
 		SDRoyalFlush,
 		func(cards []Card, c Config) bool {
 			if c.ignoreStraights || c.ignoreFlushes {
 				return false
 			}
-			flush := hasFlush(cards)
-			straight := hasStraightInShortDeck(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return cards[0].Rank() == Ace && flush && straight
 		},
-		func(cards []Card) string {
-			return "royal flush"
+		DescribeAs(DescRoyalFlush),
+	)
+
+	sdFiveOfAKind = NewDescribedRanking(
+		SDFiveOfAKind,
+		func(cards []Card, c Config) bool {
+			return HasPairs(cards, []int{5, 5, 5, 5, 5})
 		},
+		DescribeAs(DescFiveOfAKind, 0),
 	)
 )
 
-func hasStraightInShortDeck(cards []Card) bool {
-	return hasStraight(cards) || hasLowSDStraight(cards)
+// ShortDeckRankings returns the rankings of the short deck scheme in the
+// order they are checked.  The rankings can be copied into the rankings
+// of a house scheme with their Ranking values rearranged.
+func ShortDeckRankings() []RankingRule {
+	return []RankingRule{sdHighCard, sdPair, sdTwoPair, sdThreeOfAKind,
+		sdStraight, sdFlush, sdFullHouse, sdFourOfAKind, sdStraightFlush, sdRoyalFlush, sdFiveOfAKind}
 }
 
-func hasLowSDStraight(cards []Card) bool {
-	return cards[0].Rank() == Ace &&
-		cards[1].Rank() == Nine &&
-		cards[2].Rank() == Eight &&
-		cards[3].Rank() == Seven &&
-		cards[4].Rank() == Six
+// ShortDeckStraights returns the straights of a short deck from ace high
+// down to nine, eight, seven, six, ace in which the ace plays low.
+func ShortDeckStraights() [][]Rank {
+	return append(ConsecutiveStraights(Six, Ace), []Rank{Nine, Eight, Seven, Six, Ace})
 }
@@ -1,9 +1,5 @@
 package hand
 
-import (
-	"fmt"
-)
-
 const (
 	// StdHighCard represents a hand composed of no pairs, straights, or flushes.
 	// Ex: A♠ K♠ J♣ 7♥ 5♦
@@ -46,15 +42,20 @@ const (
 	// of the same suit.
 	// Ex: A♥ K♥ Q♥ J♥ T♥
 	StdRoyalFlush
+
+	// StdFiveOfAKind represents a hand composed of five cards of the same rank,
+	// which requires wild cards.
+	// Ex: A♠ A♣ A♦ A♥ 🃏
+	StdFiveOfAKind
 )
 
 var (
-	stdHighCard = NewRanking(
+	stdHighCard = NewDescribedRanking(
 		StdHighCard,
 		func(cards []Card, c Config) bool {
-			flush := hasFlush(cards)
-			straight := hasStraight(cards)
-			pairs := hasPairs(cards, []int{1, 1, 1, 1, 1})
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
+			pairs := HasPairs(cards, []int{1, 1, 1, 1, 1})
 			if !c.ignoreStraights {
 				pairs = pairs && !straight
 			}
@@ -63,130 +64,121 @@ var (
 			}
 			return pairs
 		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("high card %v high", r.singularName())
-		},
+		DescribeAs(DescHighCard, 0),
 	)
 
-	stdPair = NewRanking(
+	stdPair = NewDescribedRanking(
 		StdPair,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{2, 2, 1, 1, 1})
-		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("pair of %v", r.pluralName())
+			return HasPairs(cards, []int{2, 2, 1, 1, 1})
 		},
+		DescribeAs(DescPair, 0),
 	)
 
-	stdTwoPair = NewRanking(
+	stdTwoPair = NewDescribedRanking(
 		StdTwoPair,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{2, 2, 2, 2, 1})
-		},
-		func(cards []Card) string {
-			r1 := cards[0].Rank()
-			r2 := cards[2].Rank()
-			return fmt.Sprintf("two pair %v and %v", r1.pluralName(), r2.pluralName())
+			return HasPairs(cards, []int{2, 2, 2, 2, 1})
 		},
+		DescribeAs(DescTwoPair, 0, 2),
 	)
 
-	stdThreeOfAKind = NewRanking(
+	stdThreeOfAKind = NewDescribedRanking(
 		StdThreeOfAKind,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{3, 3, 3, 1, 1})
-		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("three of a kind %v", r.pluralName())
+			return HasPairs(cards, []int{3, 3, 3, 1, 1})
 		},
+		DescribeAs(DescThreeOfAKind, 0),
 	)
 
-	stdStraight = NewRanking(
+	stdStraight = NewDescribedRanking(
 		StdStraight,
 		func(cards []Card, c Config) bool {
 			if c.ignoreStraights {
 				return false
 			}
-			flush := hasFlush(cards)
-			straight := hasStraight(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return !flush && straight
 		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("straight %v high", r.singularName())
-		},
+		DescribeAs(DescStraight, 0),
 	)
 
-	stdFlush = NewRanking(
+	stdFlush = NewDescribedRanking(
 		StdFlush,
 		func(cards []Card, c Config) bool {
 			if c.ignoreFlushes {
 				return false
 			}
 
-			flush := hasFlush(cards)
-			straight := hasStraight(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return flush && !straight
 		},
-		func(cards []Card) string {
-			r1 := cards[0].Rank()
-			return fmt.Sprintf("flush %v high", r1.singularName())
-		},
+		DescribeAs(DescFlush, 0),
 	)
 
-	stdFullHouse = NewRanking(
+	stdFullHouse = NewDescribedRanking(
 		StdFullHouse,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{3, 3, 3, 2, 2})
-		},
-		func(cards []Card) string {
-			r1 := cards[0].Rank()
-			r2 := cards[3].Rank()
-			return fmt.Sprintf("full house %v full of %v", r1.pluralName(), r2.pluralName())
+			return HasPairs(cards, []int{3, 3, 3, 2, 2})
 		},
+		DescribeAs(DescFullHouse, 0, 3),
 	)
 
-	stdFourOfAKind = NewRanking(
+	stdFourOfAKind = NewDescribedRanking(
 		StdFourOfAKind,
 		func(cards []Card, c Config) bool {
-			return hasPairs(cards, []int{4, 4, 4, 4, 1})
-		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("four of a kind %v", r.pluralName())
+			return HasPairs(cards, []int{4, 4, 4, 4, 1})
 		},
+		DescribeAs(DescFourOfAKind, 0),
 	)
 
-	stdStraightFlush = NewRanking(
+	stdStraightFlush = NewDescribedRanking(
 		StdStraightFlush,
 		func(cards []Card, c Config) bool {
 			if c.ignoreStraights || c.ignoreFlushes {
 				return false
 			}
-			flush := hasFlush(cards)
-			straight := hasStraight(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return cards[0].Rank() != Ace && flush && straight
 		},
-		func(cards []Card) string {
-			r := cards[0].Rank()
-			return fmt.Sprintf("straight flush %v high", r.singularName())
-		},
+		DescribeAs(DescStraightFlush, 0),
 	)
 
-	stdRoyalFlush = NewRanking(
+	stdRoyalFlush = NewDescribedRanking(
 		StdRoyalFlush,
 		func(cards []Card, c Config) bool {
 			if c.ignoreStraights || c.ignoreFlushes {
 				return false
 			}
-			flush := hasFlush(cards)
-			straight := hasStraight(cards)
+			flush := HasFlush(cards)
+			straight := HasStraight(cards, c)
 			return cards[0].Rank() == Ace && flush && straight
 		},
-		func(cards []Card) string {
-			return "royal flush"
+		DescribeAs(DescRoyalFlush),
+	)
+
+	stdFiveOfAKind = NewDescribedRanking(
+		StdFiveOfAKind,
+		func(cards []Card, c Config) bool {
+			return HasPairs(cards, []int{5, 5, 5, 5, 5})
 		},
+		DescribeAs(DescFiveOfAKind, 0),
 	)
 )
+
+// StandardRankings returns the rankings of the standard scheme in the
+// order they are checked.  The rankings can be copied into the rankings
+// of a house scheme with their Ranking values rearranged.
+func StandardRankings() []RankingRule {
+	return []RankingRule{stdHighCard, stdPair, stdTwoPair, stdThreeOfAKind,
+		stdStraight, stdFlush, stdFullHouse, stdFourOfAKind, stdStraightFlush, stdRoyalFlush, stdFiveOfAKind}
+}
+
+// StandardStraights returns the straights of a standard deck from ace
+// high down to the five high wheel in which the ace plays low.
+func StandardStraights() [][]Rank {
+	return append(ConsecutiveStraights(Two, Ace), []Rank{Five, Four, Three, Two, Ace})
+}
@@ -0,0 +1,38 @@
+// Code generated by "stringer -type=Ranking -trimprefix=Std -output=ranking_string.go ranking.go ranking_standard.go badugi.go"; DO NOT EDIT.
+
+package hand
+
+import "strconv"
+
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[StdHighCard-1]
+	_ = x[StdPair-2]
+	_ = x[StdTwoPair-3]
+	_ = x[StdThreeOfAKind-4]
+	_ = x[StdStraight-5]
+	_ = x[StdFlush-6]
+	_ = x[StdFullHouse-7]
+	_ = x[StdFourOfAKind-8]
+	_ = x[StdStraightFlush-9]
+	_ = x[StdRoyalFlush-10]
+	_ = x[StdFiveOfAKind-11]
+	_ = x[BadugiFourCard-12]
+	_ = x[BadugiThreeCard-13]
+	_ = x[BadugiTwoCard-14]
+	_ = x[BadugiOneCard-15]
+}
+
+const _Ranking_name = "HighCardPairTwoPairThreeOfAKindStraightFlushFullHouseFourOfAKindStraightFlushRoyalFlushFiveOfAKindBadugiFourCardBadugiThreeCardBadugiTwoCardBadugiOneCard"
+
+var _Ranking_index = [...]uint8{0, 8, 12, 19, 31, 39, 44, 53, 64, 77, 87, 98, 112, 127, 140, 153}
+
+func (i Ranking) String() string {
+	idx := int(i) - 1
+	if i < 1 || idx >= len(_Ranking_index)-1 {
+		return "Ranking(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Ranking_name[_Ranking_index[idx]:_Ranking_index[idx+1]]
+}
@@ -0,0 +1,148 @@
+package hand
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrSchemeRegistered is returned by RegisterScheme if the game type
+// already has a scheme.
+var ErrSchemeRegistered = errors.New("hand: game type already has a ranking scheme")
+
+// A RankingScheme defines how hands of a game type are ranked.  Schemes
+// are registered with RegisterScheme under a GameType that can be passed
+// to NewDealer and to New with the WithGameType option.
+type RankingScheme interface {
+	// Name returns the name of the scheme such as "short deck".
+	Name() string
+
+	// Cards returns the unshuffled cards dealt in the scheme's game.
+	Cards() []Card
+
+	// Straights returns the straights of the scheme, each as the ranks
+	// of its cards from the highest card to the lowest, for example
+	// Five, Four, Three, Two, Ace for a wheel.
+	Straights() [][]Rank
+
+	// Rankings returns the rankings of the scheme in the order they are
+	// checked.  A hand is given the first ranking it's valid for and
+	// hands are ordered by their Ranking values, so rearranging the
+	// Ranking values of the rules changes which hands win.  Hands with
+	// duplicate cards, which can be valid for more than one ranking such
+	// as a flush with a pair, are given the highest ranking they're valid
+	// for instead.
+	Rankings() []RankingRule
+}
+
+// NewRankingScheme returns a RankingScheme from its parts.
+func NewRankingScheme(name string, cards []Card, straights [][]Rank, rankings []RankingRule) RankingScheme {
+	return &rankingScheme{
+		name:      name,
+		cards:     append([]Card{}, cards...),
+		straights: straights,
+		rankings:  append([]RankingRule{}, rankings...),
+	}
+}
+
+type rankingScheme struct {
+	name      string
+	cards     []Card
+	straights [][]Rank
+	rankings  []RankingRule
+}
+
+func (s *rankingScheme) Name() string            { return s.name }
+func (s *rankingScheme) Cards() []Card           { return append([]Card{}, s.cards...) }
+func (s *rankingScheme) Straights() [][]Rank     { return s.straights }
+func (s *rankingScheme) Rankings() []RankingRule { return s.rankings }
+
+// ConsecutiveStraights returns every straight of five consecutive ranks
+// between low and high from the highest straight to the lowest.
+func ConsecutiveStraights(low, high Rank) [][]Rank {
+	straights := [][]Rank{}
+	for top := high; top >= low+4; top-- {
+		straights = append(straights, []Rank{top, top - 1, top - 2, top - 3, top - 4})
+	}
+	return straights
+}
+
+var (
+	schemesMu sync.RWMutex
+	schemes   map[GameType]RankingScheme
+)
+
+func init() {
+	// registered in init since the rankings refer to the registry
+	schemes = map[GameType]RankingScheme{
+		GameTypeStandard:  NewRankingScheme("standard", StandardCards(), StandardStraights(), StandardRankings()),
+		GameTypeShortDeck: NewRankingScheme("short deck", ShortDeckCards(), ShortDeckStraights(), ShortDeckRankings()),
+	}
+}
+
+// RegisterScheme registers the scheme for the game type.  Game types are
+// chosen by the caller rather than assigned in the order schemes are
+// registered, so they stay the same between programs and can be stored
+// and sent to clients.  Schemes are typically registered once at package
+// initialization, for example:
+//
+//	const TripsBeatStraight hand.GameType = 100
+//
+//	func init() {
+//		if err := hand.RegisterScheme(TripsBeatStraight, scheme); err != nil {
+//			panic(err)
+//		}
+//	}
+//
+// ErrSchemeRegistered is returned if the game type, including the
+// standard and short deck game types, already has a scheme.
+func RegisterScheme(g GameType, s RankingScheme) error {
+	schemesMu.Lock()
+	defer schemesMu.Unlock()
+	if _, ok := schemes[g]; ok {
+		return ErrSchemeRegistered
+	}
+	schemes[g] = s
+	return nil
+}
+
+// Scheme returns the ranking scheme of the game type.  Game types that
+// haven't been registered are ranked with the standard scheme.
+func Scheme(g GameType) RankingScheme {
+	schemesMu.RLock()
+	defer schemesMu.RUnlock()
+	return schemes[schemeType(g)]
+}
+
+// schemeType returns the game type or GameTypeStandard if it hasn't been
+// registered.  schemesMu must be held.
+func schemeType(g GameType) GameType {
+	if _, ok := schemes[g]; !ok {
+		return GameTypeStandard
+	}
+	return g
+}
+
+// WithGameType configures NewHand to rank hands with the scheme of the
+// game type.
+func WithGameType(g GameType) func(*Config) {
+	return func(c *Config) {
+		c.gameType = g
+	}
+}
+
+// schemeRanks returns the ranks of the cards of the game type's scheme.
+func schemeRanks(g GameType) []Rank {
+	var mask uint16
+	for _, c := range Scheme(g).Cards() {
+		if !c.IsJoker() {
+			mask |= 1 << uint(c.Rank())
+		}
+	}
+	ranks := []Rank{}
+	for _, r := range allRanks() {
+		if mask&(1<<uint(r)) != 0 {
+			ranks = append(ranks, r)
+		}
+	}
+	return ranks
+}
@@ -0,0 +1,225 @@
+package hand_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+// swapRankings returns the rankings with the Ranking values of a and b
+// exchanged.
+func swapRankings(rankings []hand.RankingRule, a, b hand.Ranking) []hand.RankingRule {
+	for i, r := range rankings {
+		switch r.Ranking {
+		case a:
+			rankings[i].Ranking = b
+		case b:
+			rankings[i].Ranking = a
+		}
+	}
+	return rankings
+}
+
+const (
+	tripsBeatStraight hand.GameType = iota + 100
+	flushBeatsFullHouse
+	catchAll
+	textDescribed
+	formedWithNew
+)
+
+func init() {
+	schemes := map[hand.GameType]hand.RankingScheme{
+		tripsBeatStraight: hand.NewRankingScheme(
+			"trips beat straight",
+			hand.ShortDeckCards(),
+			hand.ShortDeckStraights(),
+			swapRankings(hand.ShortDeckRankings(), hand.SDThreeOfAKind, hand.SDStraight),
+		),
+		flushBeatsFullHouse: hand.NewRankingScheme(
+			"flush beats full house",
+			hand.StandardCards(),
+			hand.StandardStraights(),
+			swapRankings(hand.StandardRankings(), hand.StdFlush, hand.StdFullHouse),
+		),
+		// every hand is valid for the last rule, so hands are only given it
+		// if none of the standard rankings are checked first
+		catchAll: hand.NewRankingScheme(
+			"catch all",
+			hand.StandardCards(),
+			hand.StandardStraights(),
+			append(hand.StandardRankings(), hand.NewDescribedRanking(
+				hand.Ranking(99),
+				func(cards []hand.Card, c hand.Config) bool { return true },
+				hand.DescribeAs(hand.DescHighCard, 0),
+			)),
+		),
+		// rankings described with a DescFunc, which are described with the
+		// same text in every locale
+		textDescribed: hand.NewRankingScheme(
+			"text described",
+			hand.StandardCards(),
+			hand.StandardStraights(),
+			[]hand.RankingRule{hand.NewRanking(
+				hand.StdHighCard,
+				func(cards []hand.Card, c hand.Config) bool { return true },
+				func(cards []hand.Card) string { return "any hand " + cards[0].Rank().String() + " first" },
+			)},
+		),
+		// rankings that form hands themselves while the table is built
+		formedWithNew: hand.NewRankingScheme(
+			"formed with new",
+			hand.StandardCards(),
+			hand.StandardStraights(),
+			rankWithNew(hand.StandardRankings()),
+		),
+	}
+	for g, s := range schemes {
+		if err := hand.RegisterScheme(g, s); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// rankWithNew returns the rankings with Valid funcs that form the cards
+// with New and check for the ranking of the standard hand.
+func rankWithNew(rankings []hand.RankingRule) []hand.RankingRule {
+	for i := range rankings {
+		r := rankings[i].Ranking
+		rankings[i].Valid = func(cards []hand.Card, c hand.Config) bool {
+			return hand.New(cards).Ranking() == r
+		}
+	}
+	return rankings
+}
+
+var schemeTests = []struct {
+	name     string
+	gameType hand.GameType
+	winner   []hand.Card
+	loser    []hand.Card
+}{
+	{
+		"short deck flush beats full house",
+		hand.GameTypeShortDeck,
+		Cards("Ks", "Ts", "8s", "7s", "6s"),
+		Cards("As", "Ad", "Ac", "Kh", "Kd"),
+	},
+	{
+		"short deck straight beats trips",
+		hand.GameTypeShortDeck,
+		Cards("9s", "8h", "7d", "6c", "As"),
+		Cards("Ks", "Kd", "Kc", "Qh", "Jd"),
+	},
+	{
+		"trips beat straight",
+		tripsBeatStraight,
+		Cards("Ks", "Kd", "Kc", "Qh", "Jd"),
+		Cards("As", "Kh", "Qd", "Jc", "Ts"),
+	},
+	{
+		"trips beat straight flush still beats full house",
+		tripsBeatStraight,
+		Cards("Ks", "Ts", "8s", "7s", "6s"),
+		Cards("As", "Ad", "Ac", "Kh", "Kd"),
+	},
+	{
+		"flush beats full house",
+		flushBeatsFullHouse,
+		Cards("7s", "5s", "4s", "3s", "2s"),
+		Cards("As", "Ad", "Ac", "Kh", "Kd"),
+	},
+	{
+		"rankings formed with new",
+		formedWithNew,
+		Cards("As", "Ad", "Ac", "Kh", "Kd"),
+		Cards("7s", "5s", "4s", "3s", "2s"),
+	},
+	{
+		"unregistered game types are standard",
+		hand.GameType(1000),
+		Cards("As", "Ad", "Ac", "Kh", "Kd"),
+		Cards("7s", "5s", "4s", "3s", "2s"),
+	},
+}
+
+func TestSchemes(t *testing.T) {
+	for _, test := range schemeTests {
+		winner := hand.New(test.winner, hand.WithGameType(test.gameType))
+		loser := hand.New(test.loser, hand.WithGameType(test.gameType))
+		if winner.CompareTo(loser) <= 0 || winner.Value() <= loser.Value() {
+			t.Fatalf("%s: expected %v to beat %v", test.name, winner, loser)
+		}
+	}
+}
+
+func TestRegisterSchemeTwice(t *testing.T) {
+	for _, g := range []hand.GameType{hand.GameTypeStandard, hand.GameTypeShortDeck, tripsBeatStraight} {
+		if err := hand.RegisterScheme(g, hand.Scheme(flushBeatsFullHouse)); err != hand.ErrSchemeRegistered {
+			t.Fatalf("expected %v for game type %d got %v", hand.ErrSchemeRegistered, g, err)
+		}
+		if g == tripsBeatStraight && hand.Scheme(g).Name() != "trips beat straight" {
+			t.Fatalf("expected the first scheme to be kept got %q", hand.Scheme(g).Name())
+		}
+	}
+}
+
+func TestSchemeDescFunc(t *testing.T) {
+	h := hand.New(Cards("As", "Ad", "Qs", "Jh", "9c"), hand.WithGameType(textDescribed))
+	d := h.Describe()
+	if d.Kind != hand.DescText || d.Ranking != hand.StdHighCard {
+		t.Fatalf("expected a text description got %+v", d)
+	}
+	for _, l := range []*hand.Locale{hand.English, hand.Vietnamese} {
+		if s := h.LocalDescription(l); s != "any hand A first" || h.Description() != s {
+			t.Fatalf("expected %q in %s got %q", "any hand A first", l.Tag, s)
+		}
+	}
+}
+
+func TestSchemeRankingOrder(t *testing.T) {
+	tests := []struct {
+		cards   []hand.Card
+		ranking hand.Ranking
+	}{
+		{Cards("As", "Ks", "Qs", "Js", "Ts"), hand.StdRoyalFlush},
+		{Cards("As", "Ad", "Qs", "Jh", "9c"), hand.StdPair},
+		{Cards("As", "Kd", "Qs", "Jh", "9c", "2c", "3d"), hand.StdHighCard},
+	}
+	for _, test := range tests {
+		h := hand.New(test.cards, hand.WithGameType(catchAll))
+		if h.Ranking() != test.ranking {
+			t.Fatalf("expected the first valid ranking %v got %v", test.ranking, h)
+		}
+	}
+}
+
+func TestShortDeckLowStraight(t *testing.T) {
+	cards := Cards("As", "9h", "8d", "7c", "6s", "Kd", "Kc")
+	h := hand.New(cards, hand.ShortDeck)
+	if h.Ranking() != hand.SDStraight {
+		t.Fatalf("expected straight got %v", h)
+	}
+	expected := Cards("9h", "8d", "7c", "6s", "As")
+	for i := range expected {
+		if h.Cards()[i] != expected[i] {
+			t.Fatalf("expected %v got %v", expected, h.Cards())
+		}
+	}
+	if h.Description() != "straight nine high" {
+		t.Fatalf("expected straight nine high got %v", h.Description())
+	}
+}
+
+func TestSchemeDealer(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	deck := hand.NewDealer(r, tripsBeatStraight).Deck()
+	if len(deck.Cards) != 36 {
+		t.Fatalf("expected 36 cards got %d", len(deck.Cards))
+	}
+	if name := hand.Scheme(tripsBeatStraight).Name(); name != "trips beat straight" {
+		t.Fatalf("expected trips beat straight got %s", name)
+	}
+}
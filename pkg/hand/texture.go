@@ -0,0 +1,260 @@
+package hand
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+// A Pairing describes the paired ranks of a board.
+type Pairing int
+
+const (
+	// Unpaired is a board without two cards of the same rank.
+	Unpaired Pairing = iota
+
+	// Paired is a board with one pair.
+	Paired
+
+	// TwoPaired is a board with two pairs.
+	TwoPaired
+
+	// TripsBoard is a board with three cards of the same rank.
+	TripsBoard
+
+	// FullHouseBoard is a board with three of a kind and a pair.
+	FullHouseBoard
+
+	// QuadsBoard is a board with four cards of the same rank.
+	QuadsBoard
+)
+
+var pairingNames = []string{"unpaired", "paired", "two-paired", "trips", "full house", "quads"}
+
+// String returns the name of the pairing such as "paired".
+func (p Pairing) String() string {
+	return pairingNames[p]
+}
+
+// A SuitTexture describes how the suits of a board are distributed.
+type SuitTexture int
+
+const (
+	// Rainbow is a board without two cards of the same suit.
+	Rainbow SuitTexture = iota
+
+	// TwoTone is a board with cards of the same suit that isn't
+	// monotone.
+	TwoTone
+
+	// Monotone is a board of a single suit.
+	Monotone
+)
+
+var suitTextureNames = []string{"rainbow", "two-tone", "monotone"}
+
+// String returns the name of the suit texture such as "two-tone".
+func (s SuitTexture) String() string {
+	return suitTextureNames[s]
+}
+
+// Connectedness describes how close the ranks of a board are to making a
+// straight.
+type Connectedness int
+
+const (
+	// Disconnected is a board without two ranks of any straight.
+	Disconnected Connectedness = iota
+
+	// SemiConnected is a board with two ranks of a straight, so straight
+	// draws are possible but straights aren't.
+	SemiConnected
+
+	// Connected is a board with three or more ranks of a straight, so a
+	// straight is possible.
+	Connected
+)
+
+var connectednessNames = []string{"disconnected", "semi-connected", "connected"}
+
+// String returns the name of the connectedness such as "connected".
+func (c Connectedness) String() string {
+	return connectednessNames[c]
+}
+
+// A HighCardBand groups boards by their highest card.
+type HighCardBand int
+
+const (
+	// LowBoard is a board whose highest card is six or lower.
+	LowBoard HighCardBand = iota
+
+	// MiddleBoard is a board whose highest card is seven, eight, or nine.
+	MiddleBoard
+
+	// BroadwayBoard is a board whose highest card is ten through king.
+	BroadwayBoard
+
+	// AceHighBoard is a board with an ace.
+	AceHighBoard
+)
+
+var highCardBandNames = []string{"low", "middle", "broadway", "ace-high"}
+
+// String returns the name of the band such as "broadway".
+func (b HighCardBand) String() string {
+	return highCardBandNames[b]
+}
+
+// A Texture describes a board of three to five cards.  Jokers don't count
+// toward the ranks, suits, or high card of the board.  PossibleHands holds
+// every Ranking that some hole cards make with the board in ascending
+// order of strength, so low hands are in descending order of Ranking.
+type Texture struct {
+	Board            []Card
+	Pairing          Pairing
+	Suits            SuitTexture
+	Connectedness    Connectedness
+	StraightPossible bool
+	FlushPossible    bool
+	HighCard         Rank
+	Band             HighCardBand
+	PossibleHands    []Ranking
+}
+
+// NewTexture describes the board under the ranking scheme of the given
+// options.  NewTexture panics if the board isn't three to five cards.
+func NewTexture(board []Card, options ...func(*Config)) *Texture {
+	if len(board) < 3 || len(board) > 5 {
+		panic("hand: texture requires a board of three to five cards")
+	}
+	c := &Config{}
+	for _, option := range options {
+		option(c)
+	}
+	t := &Texture{Board: append([]Card{}, board...)}
+
+	counts := map[Rank]int{}
+	suits := map[Suit]int{}
+	maxSuit := 0
+	for _, card := range board {
+		if card.IsJoker() {
+			continue
+		}
+		counts[card.Rank()]++
+		suits[card.Suit()]++
+		maxSuit = max(maxSuit, suits[card.Suit()])
+		if card.Rank() > t.HighCard {
+			t.HighCard = card.Rank()
+		}
+	}
+	t.Pairing = pairing(counts)
+	switch {
+	case len(suits) == 1:
+		t.Suits = Monotone
+	case maxSuit > 1:
+		t.Suits = TwoTone
+	}
+	t.FlushPossible = maxSuit >= 3
+
+	ranks := rankMask(board)
+	overlap := 0
+	for _, s := range straightMasks(c.gameType) {
+		overlap = max(overlap, bits.OnesCount16(ranks&s))
+	}
+	switch {
+	case overlap >= 3:
+		t.Connectedness = Connected
+	case overlap == 2:
+		t.Connectedness = SemiConnected
+	}
+	t.StraightPossible = t.Connectedness == Connected
+
+	switch {
+	case t.HighCard == Ace:
+		t.Band = AceHighBoard
+	case t.HighCard >= Ten:
+		t.Band = BroadwayBoard
+	case t.HighCard >= Seven:
+		t.Band = MiddleBoard
+	}
+
+	t.PossibleHands = possibleHands(board, c, options)
+	return t
+}
+
+func pairing(counts map[Rank]int) Pairing {
+	pairs, trips := 0, 0
+	for _, n := range counts {
+		switch n {
+		case 4:
+			return QuadsBoard
+		case 3:
+			trips++
+		case 2:
+			pairs++
+		}
+	}
+	switch {
+	case trips > 0 && pairs > 0:
+		return FullHouseBoard
+	case trips > 0:
+		return TripsBoard
+	case pairs > 1:
+		return TwoPaired
+	case pairs == 1:
+		return Paired
+	}
+	return Unpaired
+}
+
+// possibleHands returns the rankings of every hole cards from the unseen
+// cards of the game type's scheme with the board, weakest first under the
+// config's sorting.
+func possibleHands(board []Card, c *Config, options []func(*Config)) []Ranking {
+	known := NewCardSet(board...)
+	unseen := []Card{}
+	for _, card := range Scheme(c.gameType).Cards() {
+		if !known.Contains(card) && !card.IsJoker() {
+			unseen = append(unseen, card)
+		}
+	}
+	seen := map[Ranking]bool{}
+	rankings := []Ranking{}
+	for i, c0 := range unseen {
+		for _, c1 := range unseen[i+1:] {
+			r := New(append([]Card{c0, c1}, board...), options...).Ranking()
+			if !seen[r] {
+				seen[r] = true
+				rankings = append(rankings, r)
+			}
+		}
+	}
+	low := c.sorting == SortingLow
+	sort.Slice(rankings, func(i, j int) bool { return (rankings[i] < rankings[j]) != low })
+	return rankings
+}
+
+// Description returns a short label of the board such as "Q-high
+// two-tone connected" or "A-high paired rainbow disconnected".
+func (t *Texture) Description() string {
+	parts := []string{t.HighCard.String() + "-high"}
+	if t.Pairing != Unpaired {
+		parts = append(parts, t.Pairing.String())
+	}
+	parts = append(parts, t.Suits.String(), t.Connectedness.String())
+	return strings.Join(parts, " ")
+}
+
+// String returns the description followed by the board.
+func (t *Texture) String() string {
+	return fmt.Sprintf("%s %v", t.Description(), t.Board)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,43 @@
+package hand_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+var textureTests = []struct {
+	board       []hand.Card
+	options     []func(*hand.Config)
+	description string
+	band        hand.HighCardBand
+	best        hand.Ranking
+}{
+	{Cards("Qh", "Jh", "9c"), nil, "Q-high two-tone connected", hand.BroadwayBoard, hand.StdStraight},
+	{Cards("Ad", "7s", "2c"), nil, "A-high rainbow semi-connected", hand.AceHighBoard, hand.StdThreeOfAKind},
+	{Cards("Kd", "Kh", "7d", "2d"), nil, "K-high paired two-tone disconnected", hand.BroadwayBoard, hand.StdFourOfAKind},
+	{Cards("6s", "5s", "4s", "3s", "2s"), nil, "6-high monotone connected", hand.LowBoard, hand.StdStraightFlush},
+	{Cards("8c", "8d", "8h", "9s", "9c"), nil, "9-high full house two-tone semi-connected", hand.MiddleBoard, hand.StdFourOfAKind},
+	{Cards("Ac", "9d", "7h"), []func(*hand.Config){hand.ShortDeck}, "A-high rainbow connected", hand.AceHighBoard, hand.SDStraight},
+	{hand.MustParseCards("BJ 2h 3d"), nil, "3-high rainbow semi-connected", hand.LowBoard, hand.StdFourOfAKind},
+	{Cards("Kc", "Qd", "Jh"), []func(*hand.Config){hand.AceToFiveLow}, "K-high rainbow connected", hand.BroadwayBoard, hand.StdHighCard},
+}
+
+func TestTexture(t *testing.T) {
+	for _, test := range textureTests {
+		tex := hand.NewTexture(test.board, test.options...)
+		if tex.Description() != test.description {
+			t.Fatalf("expected %s got %s", test.description, tex.Description())
+		}
+		if tex.Band != test.band {
+			t.Fatalf("%v: expected %v got %v", test.board, test.band, tex.Band)
+		}
+		if best := tex.PossibleHands[len(tex.PossibleHands)-1]; best != test.best {
+			t.Fatalf("%v: expected %v to be the best possible hand got %v", test.board, test.best, best)
+		}
+		if tex.StraightPossible != (tex.Connectedness == hand.Connected) {
+			t.Fatalf("%v: expected straights to be possible on connected boards got %+v", test.board, tex)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package hand
+
+// Wild configures NewHand to treat cards of the given ranks as wild, for
+// example Wild(Two) for deuces wild.  A wild card may be used as any
+// card, including a card already in the hand, so five of a kind is
+// possible.  Jokers are always wild.
+func Wild(ranks ...Rank) func(*Config) {
+	return func(c *Config) {
+		for _, r := range ranks {
+			c.wilds |= 1 << uint(r)
+		}
+	}
+}
+
+// JokersWild configures NewHand to treat jokers as wild cards that may be
+// used as any card.  Jokers are wild even without this option, but hands
+// should be formed with it so that hands with and without jokers are
+// ranked with the same rankings.
+func JokersWild(c *Config) {
+	c.jokersWild = true
+}
+
+// JokerBug configures NewHand to treat jokers as the "bug": a joker may
+// only be used as an ace or to complete a straight or a flush.
+func JokerBug(c *Config) {
+	c.jokerBug = true
+}
+
+// hasWilds returns true if the configuration allows wild cards.
+func (c *Config) hasWilds() bool {
+	return c.wilds != 0 || c.jokersWild || c.jokerBug
+}
+
+// isWild returns true if the card is wild under the configuration.
+func (c *Config) isWild(card Card) bool {
+	return card.IsJoker() || c.wilds&(1<<uint(card.Rank())) != 0
+}
+
+func (c *Config) wildRanks() []Rank {
+	ranks := []Rank{}
+	for _, r := range allRanks() {
+		if c.wilds&(1<<uint(r)) != 0 {
+			ranks = append(ranks, r)
+		}
+	}
+	if len(ranks) == 0 {
+		return nil
+	}
+	return ranks
+}
+
+// A WildCard records the card that a wild card was used as.
+type WildCard struct {
+	Wild Card
+	As   Card
+}
+
+// String returns a string in the format "🃏 as A♠"
+func (w WildCard) String() string {
+	return w.Wild.String() + " as " + w.As.String()
+}
+
+// WildCards returns the wild cards in the hand and the cards they were
+// used as in the order of the cards returned by Cards.
+func (h *Hand) WildCards() []WildCard {
+	return append([]WildCard{}, h.wilds...)
+}
+
+// formedCards returns the cards of the hand with its wild cards replaced
+// by the cards they were used as.
+func (h *Hand) formedCards() []Card {
+	cards := make([]Card, len(h.cards))
+	wilds := h.wilds
+	for i, card := range h.cards {
+		if len(wilds) > 0 && card == wilds[0].Wild {
+			card = wilds[0].As
+			wilds = wilds[1:]
+		}
+		cards[i] = card
+	}
+	return cards
+}
+
+// wildSelection tracks the highest (or lowest if low is true) value out
+// of the five card combinations it considers after replacing their wild
+// cards with every card they could be used as.  subs holds the selected
+// combination with its wild cards replaced.
+type wildSelection struct {
+	selection
+	c     *Config
+	ranks []Rank
+	subs  [5]Card
+}
+
+func newWildSelection(t *evalTable, c *Config) *wildSelection {
+	return &wildSelection{
+		selection: selection{t: t, low: c.sorting == SortingLow},
+		c:         c,
+		ranks:     schemeRanks(c.gameType),
+	}
+}
+
+func (s *wildSelection) consider(c0, c1, c2, c3, c4 Card) {
+	cards := [5]Card{c0, c1, c2, c3, c4}
+	wilds := []int{}
+	bugs := 0
+	suit := NoSuit
+	suited := true
+	for i, card := range cards {
+		if s.c.isWild(card) {
+			wilds = append(wilds, i)
+			if card.IsJoker() && s.c.jokerBug {
+				bugs++
+			}
+			continue
+		}
+		if suit == NoSuit {
+			suit = card.Suit()
+		}
+		suited = suited && card.Suit() == suit
+	}
+	if len(wilds) == 0 {
+		s.try(cards, cards, 0)
+		return
+	}
+	if suit == NoSuit {
+		suit = Spades
+	}
+	// bug jokers are moved to the front so they are given the highest
+	// ranks, which are aces if any are
+	for i, j := 0, 0; i < len(wilds); i++ {
+		if cards[wilds[i]].IsJoker() && s.c.jokerBug {
+			wilds[i], wilds[j] = wilds[j], wilds[i]
+			j++
+		}
+	}
+	// ranks are enumerated as multisets with the highest ranks given to
+	// the first wild cards, which are the bug jokers
+	subs := cards
+	idx := make([]int, len(wilds))
+	for {
+		for i, w := range wilds {
+			subs[w] = getCard(s.ranks[idx[len(idx)-1-i]], suit)
+		}
+		if suited {
+			s.try(cards, subs, bugs)
+		}
+		// the same ranks without a flush
+		subs[wilds[0]] = getCard(subs[wilds[0]].Rank(), (suit+1)%4)
+		s.try(cards, subs, bugs)
+
+		i := len(idx) - 1
+		for i >= 0 && idx[i] == len(s.ranks)-1 {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		for j := i + 1; j < len(idx); j++ {
+			idx[j] = idx[i]
+		}
+	}
+}
+
+// try considers the cards with their wild cards replaced by subs.  If
+// there are bug jokers they must be used as aces unless the hand is a
+// straight or a flush.
+func (s *wildSelection) try(cards, subs [5]Card, bugs int) {
+	v := s.t.lookup(subs[0], subs[1], subs[2], subs[3], subs[4])
+	if v == 0 {
+		panic("unreachable")
+	}
+	if bugs > 0 {
+		class := s.t.classes[v]
+		if !class.straight && !class.flush && !jokersAreAces(cards, subs) {
+			return
+		}
+	}
+	better := v > s.value
+	if s.low {
+		better = s.value == 0 || v < s.value
+	}
+	if better {
+		s.value = v
+		s.cards = cards
+		s.subs = subs
+	}
+}
+
+func jokersAreAces(cards, subs [5]Card) bool {
+	for i := range cards {
+		if cards[i].IsJoker() && subs[i].Rank() != Ace {
+			return false
+		}
+	}
+	return true
+}
+
+// bestWild returns the best hand out of the combinations that walk passes
+// to its function with wild cards replaced.
+func (t *evalTable) bestWild(c *Config, walk func(f func(c0, c1, c2, c3, c4 Card))) *Hand {
+	s := newWildSelection(t, c)
+	walk(s.consider)
+	return t.wildHand(s.cards, s.subs, s.value, c)
+}
+
+// hasJoker returns true if any of the cards is a joker.
+func hasJoker(cards []Card) bool {
+	for _, c := range cards {
+		if c.IsJoker() {
+			return true
+		}
+	}
+	return false
+}
+
+// wildHand forms the Hand for the five cards whose wild cards were
+// replaced by subs.  Wild cards are placed where the card they were used
+// as would be and the description notes what they were used as.
+func (t *evalTable) wildHand(cards, subs [5]Card, v uint16, c *Config) *Hand {
+	h := t.hand(subs, v, c)
+	restoreWilds(h, cards[:], subs[:])
+	return h
+}
+
+// restoreWilds places the wild cards of cards where the card they were
+// replaced with by subs is in the hand and notes what they were used as
+// in the description.
+func restoreWilds(h *Hand, cards, subs []Card) {
+	used := make([]bool, len(subs))
+	for i, sub := range h.cards {
+		for j := range subs {
+			if used[j] || subs[j] != sub {
+				continue
+			}
+			used[j] = true
+			h.cards[i] = cards[j]
+			if cards[j] != sub {
+				h.wilds = append(h.wilds, WildCard{Wild: cards[j], As: sub})
+			}
+			break
+		}
+	}
+	if len(h.wilds) > 0 {
+		h.desc.Wilds = h.wilds
+		h.description = h.desc.String()
+	}
+}
+
+// hasWild returns true if any of the cards is wild under the
+// configuration.
+func hasWild(cards []Card, c *Config) bool {
+	for _, card := range cards {
+		if c.isWild(card) {
+			return true
+		}
+	}
+	return false
+}
+
+// newShortWild returns the best hand of less than five cards after
+// replacing its wild cards with every rank they could be used as.  Short
+// hands can't make straights or flushes, so wild cards are used as
+// spades and bug jokers only as aces.
+func newShortWild(cards []Card, c *Config) *Hand {
+	wilds := []int{}
+	for i, card := range cards {
+		if c.isWild(card) {
+			wilds = append(wilds, i)
+		}
+	}
+	ranks := schemeRanks(c.gameType)
+	low := c.sorting == SortingLow
+	subs := append([]Card{}, cards...)
+	var best *Hand
+	var bestSubs []Card
+	var walk func(i int)
+	walk = func(i int) {
+		if i == len(wilds) {
+			h := handForFiveCards(append([]Card{}, subs...), *c)
+			if best == nil || !low && h.CompareTo(best) > 0 || low && h.CompareTo(best) < 0 {
+				best, bestSubs = h, append([]Card{}, subs...)
+			}
+			return
+		}
+		for _, r := range ranks {
+			if c.jokerBug && cards[wilds[i]].IsJoker() && r != Ace {
+				continue
+			}
+			subs[wilds[i]] = getCard(r, Spades)
+			walk(i + 1)
+		}
+	}
+	walk(0)
+	best.config = c
+	restoreWilds(best, cards, bestSubs)
+	return best
+}
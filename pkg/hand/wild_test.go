@@ -0,0 +1,145 @@
+package hand_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+var wildTests = []testOptionsPairs{
+	{
+		Cards("2s", "As", "Ad", "Ac", "Kh"),
+		Cards("2s", "As", "Ad", "Ac", "Kh"),
+		[]func(*hand.Config){hand.Wild(hand.Two)},
+		hand.StdFourOfAKind,
+		"four of a kind aces (2♠ as ace)",
+	},
+	{
+		append(Cards("As", "Ks", "Qs", "Js", "4d"), hand.BlackJoker),
+		append(Cards("As", "Ks", "Qs", "Js"), hand.BlackJoker),
+		[]func(*hand.Config){hand.JokersWild},
+		hand.StdRoyalFlush,
+		"royal flush (🃏 as T♠)",
+	},
+	{
+		append(Cards("As", "Ad", "Ac", "Ah"), hand.RedJoker),
+		append(Cards("As", "Ad", "Ac", "Ah"), hand.RedJoker),
+		nil,
+		hand.StdFiveOfAKind,
+		"five of a kind aces (🂿 as ace)",
+	},
+	{
+		append(Cards("Kh", "Kd", "7c", "3s"), hand.BlackJoker),
+		append(Cards("Kh", "Kd"), hand.BlackJoker, hand.SevenClubs, hand.ThreeSpades),
+		[]func(*hand.Config){hand.JokerBug},
+		hand.StdPair,
+		"pair of kings (🃏 as ace)",
+	},
+	{
+		append(Cards("9h", "Th", "Jc", "Qd"), hand.BlackJoker),
+		append([]hand.Card{hand.BlackJoker}, Cards("Qd", "Jc", "Th", "9h")...),
+		[]func(*hand.Config){hand.JokerBug},
+		hand.StdStraight,
+		"straight king high (🃏 as king)",
+	},
+	{
+		Cards("2s", "2d", "7h", "5h", "4h"),
+		Cards("2s", "7h", "2d", "5h", "4h"),
+		[]func(*hand.Config){hand.Wild(hand.Two)},
+		hand.StdStraightFlush,
+		"straight flush eight high (2♠ as 8♥, 2♦ as 6♥)",
+	},
+	{
+		[]hand.Card{hand.BlackJoker},
+		[]hand.Card{hand.BlackJoker},
+		nil,
+		hand.StdHighCard,
+		"high card ace high (🃏 as ace)",
+	},
+	{
+		append(Cards("As"), hand.RedJoker),
+		append(Cards("As"), hand.RedJoker),
+		nil,
+		hand.StdPair,
+		"pair of aces (🂿 as ace)",
+	},
+	{
+		Cards("Kh", "2s", "Kd"),
+		Cards("Kh", "2s", "Kd"),
+		[]func(*hand.Config){hand.Wild(hand.Two)},
+		hand.StdThreeOfAKind,
+		"three of a kind kings (2♠ as king)",
+	},
+}
+
+func TestWildHands(t *testing.T) {
+	for _, test := range wildTests {
+		h := hand.New(test.cards, test.options...)
+		if h.Ranking() != test.ranking {
+			t.Fatalf("expected %v got %v", test.ranking, h)
+		}
+		if len(h.Cards()) != len(test.arrangement) {
+			t.Fatalf("expected %v got %v", test.arrangement, h.Cards())
+		}
+		for i := range test.arrangement {
+			actual, expected := h.Cards()[i], test.arrangement[i]
+			if actual != expected {
+				t.Fatalf("expected %v got %v", test.arrangement, h.Cards())
+			}
+		}
+		if test.description != h.Description() {
+			t.Fatalf("expected \"%v\" got \"%v\"", test.description, h.Description())
+		}
+	}
+}
+
+func TestWildCards(t *testing.T) {
+	h := hand.New(append(Cards("Kh", "Kd", "7c", "3s"), hand.BlackJoker), hand.JokersWild)
+	wilds := h.WildCards()
+	if len(wilds) != 1 || wilds[0].Wild != hand.BlackJoker || wilds[0].As.Rank() != hand.King {
+		t.Fatalf("expected the joker to be used as a king got %v", wilds)
+	}
+	if hand.ValueCount(hand.JokersWild) != hand.ValueCount()+13 {
+		t.Fatalf("expected thirteen five of a kind values got %d", hand.ValueCount(hand.JokersWild))
+	}
+	natural := hand.New(Cards("Ks", "Kc", "Kh", "3s", "3d"), hand.JokersWild)
+	if h.CompareTo(natural) >= 0 {
+		t.Fatalf("expected %v to lose to %v", h, natural)
+	}
+}
+
+func TestJokers(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	dealer, err := hand.NewDealerWithJokers(r, hand.GameTypeStandard, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deck := dealer.Deck()
+	set := deck.CardSet()
+	if len(deck.Cards) != 54 || !set.Contains(hand.BlackJoker) || !set.Contains(hand.RedJoker) {
+		t.Fatalf("expected a deck with two jokers got %v", deck)
+	}
+	for _, joker := range hand.Jokers(2) {
+		text, err := joker.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var c hand.Card
+		if err := c.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if c != joker || c.Rank() != hand.Joker || c.Suit() != hand.NoSuit {
+			t.Fatalf("expected %v got %v", joker, c)
+		}
+	}
+	for _, n := range []int{0, 3, -1} {
+		if _, err := hand.NewDealerWithJokers(r, hand.GameTypeStandard, n); err != hand.ErrInvalidJokers {
+			t.Fatalf("expected %v for %d jokers got %v", hand.ErrInvalidJokers, n, err)
+		}
+	}
+	if len(hand.Jokers(3)) != 2 || len(hand.Jokers(-1)) != 0 {
+		t.Fatalf("expected the number of jokers to be clamped got %v %v", hand.Jokers(3), hand.Jokers(-1))
+	}
+}
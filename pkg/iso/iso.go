@@ -0,0 +1,193 @@
+// Package iso maps sets of cards to canonical forms under suit
+// permutation.  Sets that only differ by a relabeling of suits, such as
+// A♠K♠ and A♥K♥, play the same and share a canonical form, so solvers and
+// caches can key tables by the dense index of the canonical form.
+package iso
+
+import (
+	"errors"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/util"
+)
+
+// ErrInvalidCards is returned when cards can't be indexed, for example
+// because there are duplicates or cards that aren't in the deck.
+var ErrInvalidCards = errors.New("iso: invalid cards")
+
+// Canonical returns the canonical form of the cards: suits are relabeled
+// in the order spades, hearts, diamonds, clubs from the suit with the
+// most cards to the least, breaking ties by the highest ranks.  The cards
+// are returned in ascending order of card value.
+func Canonical(cards []hand.Card) []hand.Card {
+	return canonical(hand.NewCardSet(cards...)).Cards()
+}
+
+func canonical(s hand.CardSet) hand.CardSet {
+	var masks [4]uint16
+	for i := range masks {
+		masks[i] = s.SuitRanks(hand.Suit(i))
+	}
+	sort.Slice(masks[:], func(i, j int) bool {
+		ci, cj := bits.OnesCount16(masks[i]), bits.OnesCount16(masks[j])
+		if ci != cj {
+			return ci > cj
+		}
+		return masks[i] > masks[j]
+	})
+	var c hand.CardSet
+	for i, m := range masks {
+		c |= hand.CardSet(m) << (13 * uint(i))
+	}
+	return c
+}
+
+// Isomorphs returns every distinct set of cards that the cards become
+// under suit permutation, including the cards themselves.  Each set is in
+// ascending order of card value.
+func Isomorphs(cards []hand.Card) [][]hand.Card {
+	s := hand.NewCardSet(cards...)
+	seen := map[hand.CardSet]bool{}
+	isomorphs := [][]hand.Card{}
+	forEachPermutation(func(perm [4]hand.Suit) {
+		var p hand.CardSet
+		for i, to := range perm {
+			p |= hand.CardSet(s.SuitRanks(hand.Suit(i))) << (13 * uint(to))
+		}
+		if !seen[p] {
+			seen[p] = true
+			isomorphs = append(isomorphs, p.Cards())
+		}
+	})
+	return isomorphs
+}
+
+// forEachPermutation calls f with every permutation of the four suits.
+func forEachPermutation(f func(perm [4]hand.Suit)) {
+	perm := [4]hand.Suit{hand.Spades, hand.Hearts, hand.Diamonds, hand.Clubs}
+	var permute func(k int)
+	permute = func(k int) {
+		if k == len(perm) {
+			f(perm)
+			return
+		}
+		for i := k; i < len(perm); i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			permute(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	permute(0)
+}
+
+// An Indexer maps sets of a fixed number of cards from a deck to dense
+// indexes of their canonical forms.  Indexes are assigned in ascending
+// order of the canonical forms' card sets.
+type Indexer struct {
+	deck    hand.CardSet
+	size    int
+	forms   []hand.CardSet
+	weights []int
+	index   map[hand.CardSet]int
+}
+
+type indexerKey struct {
+	deck hand.CardSet
+	size int
+}
+
+var (
+	indexersMu sync.Mutex
+	indexers   = map[indexerKey]*Indexer{}
+)
+
+// NewIndexer returns the Indexer of sets of size cards from the deck,
+// such as hand.StandardCards() or hand.ShortDeckCards().  Indexers are
+// built by visiting every set of the deck once and are shared between
+// calls with the same deck and size.  NewIndexer panics if size isn't
+// between one and the number of cards in the deck.
+func NewIndexer(deck []hand.Card, size int) *Indexer {
+	set := hand.NewCardSet(deck...)
+	if size < 1 || size > set.Count() {
+		panic("iso: size must be between one and the number of cards in the deck")
+	}
+	key := indexerKey{deck: set, size: size}
+	indexersMu.Lock()
+	defer indexersMu.Unlock()
+	if ix, ok := indexers[key]; ok {
+		return ix
+	}
+	ix := buildIndexer(set, size)
+	indexers[key] = ix
+	return ix
+}
+
+// Preflop returns the Indexer of hole cards for the deck, which has the
+// 169 starting hand classes of a standard deck.
+func Preflop(deck []hand.Card) *Indexer {
+	return NewIndexer(deck, 2)
+}
+
+// Flop returns the Indexer of flops for the deck, which has the 1,755
+// strategically different flops of a standard deck.
+func Flop(deck []hand.Card) *Indexer {
+	return NewIndexer(deck, 3)
+}
+
+func buildIndexer(deck hand.CardSet, size int) *Indexer {
+	cards := deck.Cards()
+	counts := map[hand.CardSet]int{}
+	util.ForEachCombination(len(cards), size, func(idx []int) bool {
+		var s hand.CardSet
+		for _, i := range idx {
+			s.Add(cards[i])
+		}
+		counts[canonical(s)]++
+		return true
+	})
+	ix := &Indexer{
+		deck:  deck,
+		size:  size,
+		index: make(map[hand.CardSet]int, len(counts)),
+	}
+	for form := range counts {
+		ix.forms = append(ix.forms, form)
+	}
+	sort.Slice(ix.forms, func(i, j int) bool { return ix.forms[i] < ix.forms[j] })
+	for i, form := range ix.forms {
+		ix.index[form] = i
+		ix.weights = append(ix.weights, counts[form])
+	}
+	return ix
+}
+
+// Len returns the number of canonical forms.
+func (ix *Indexer) Len() int {
+	return len(ix.forms)
+}
+
+// Index returns the index of the canonical form of the cards.  Index
+// returns ErrInvalidCards if the cards aren't the Indexer's number of
+// distinct cards from its deck.
+func (ix *Indexer) Index(cards []hand.Card) (int, error) {
+	s := hand.NewCardSet(cards...)
+	if len(cards) != ix.size || s.Count() != ix.size || !ix.deck.ContainsAll(s) {
+		return 0, ErrInvalidCards
+	}
+	return ix.index[canonical(s)], nil
+}
+
+// Cards returns the canonical form of the index in ascending order of
+// card value.  Cards panics if the index is out of range.
+func (ix *Indexer) Cards(index int) []hand.Card {
+	return ix.forms[index].Cards()
+}
+
+// Weight returns the number of sets of cards of the deck whose canonical
+// form has the index.  Weight panics if the index is out of range.
+func (ix *Indexer) Weight(index int) int {
+	return ix.weights[index]
+}
@@ -0,0 +1,80 @@
+package iso_test
+
+import (
+	"testing"
+
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/iso"
+	. "github.com/notnil/joker/pkg/jokertest"
+)
+
+var indexerTests = []struct {
+	deck  []hand.Card
+	size  int
+	forms int
+	sets  int
+}{
+	{hand.StandardCards(), 2, 169, 1326},
+	{hand.StandardCards(), 3, 1755, 22100},
+	{hand.StandardCards(), 4, 16432, 270725},
+	{hand.StandardCards(), 5, 134459, 2598960},
+	{hand.ShortDeckCards(), 2, 81, 630},
+	{hand.ShortDeckCards(), 3, 573, 7140},
+}
+
+func TestIndexer(t *testing.T) {
+	for _, test := range indexerTests {
+		if testing.Short() && test.size > 4 {
+			continue
+		}
+		ix := iso.NewIndexer(test.deck, test.size)
+		if ix.Len() != test.forms {
+			t.Fatalf("expected %d forms of %d cards got %d", test.forms, test.size, ix.Len())
+		}
+		sets := 0
+		for i := 0; i < ix.Len(); i++ {
+			cards := ix.Cards(i)
+			if index, err := ix.Index(cards); err != nil || index != i {
+				t.Fatalf("expected %v to have index %d got %d %v", cards, i, index, err)
+			}
+			if w := len(iso.Isomorphs(cards)); w != ix.Weight(i) {
+				t.Fatalf("expected %v to have weight %d got %d", cards, w, ix.Weight(i))
+			}
+			sets += ix.Weight(i)
+		}
+		if sets != test.sets {
+			t.Fatalf("expected weights to add up to %d got %d", test.sets, sets)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	tests := []struct {
+		cards     []hand.Card
+		canonical []hand.Card
+	}{
+		{Cards("Ah", "Kh"), Cards("Ks", "As")},
+		{Cards("Kd", "Ac"), Cards("As", "Kh")},
+		{Cards("2c", "7d", "7c"), Cards("2s", "7s", "7h")},
+		{Cards("Qd", "Jd", "Td"), Cards("Ts", "Js", "Qs")},
+	}
+	for _, test := range tests {
+		actual := iso.Canonical(test.cards)
+		if hand.NewCardSet(actual...) != hand.NewCardSet(test.canonical...) {
+			t.Fatalf("expected %v got %v", test.canonical, actual)
+		}
+	}
+	ix := iso.Preflop(hand.StandardCards())
+	i1, _ := ix.Index(Cards("Ah", "Kh"))
+	i2, _ := ix.Index(Cards("Ac", "Kc"))
+	i3, _ := ix.Index(Cards("Ac", "Kd"))
+	if i1 != i2 || i1 == i3 || ix.Weight(i1) != 4 || ix.Weight(i3) != 12 {
+		t.Fatalf("expected suited and offsuit classes got %d %d %d", i1, i2, i3)
+	}
+	if _, err := ix.Index(Cards("Ah", "Ah")); err != iso.ErrInvalidCards {
+		t.Fatalf("expected %v got %v", iso.ErrInvalidCards, err)
+	}
+	if _, err := iso.Preflop(hand.ShortDeckCards()).Index(Cards("Ah", "2h")); err != iso.ErrInvalidCards {
+		t.Fatalf("expected %v got %v", iso.ErrInvalidCards, err)
+	}
+}
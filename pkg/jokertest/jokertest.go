@@ -36,20 +36,17 @@ func Deck5() *hand.Deck {
 func parseDeck(s string) *hand.Deck {
 	cards := []hand.Card{}
 	for _, cardStr := range strings.Split(s, " ") {
-		temp := hand.AceSpades
-		c := &temp
-		if err := c.UnmarshalText([]byte(cardStr)); err != nil {
-			panic(err)
-		}
-		cards = append(cards, *c)
+		cards = append(cards, card(cardStr))
 	}
 	return &hand.Deck{Cards: cards}
 }
 
 // Cards takes a list of strings that have the format "4s", "Tc",
 // "Ah" instead of the hand.Card String() format "4♠", "T♣", "A♥"
-// for ease of testing.  If a string is invalid Cards panics,
-// otherwise it returns a list of the corresponding cards.
+// for ease of testing, although any format accepted by hand.ParseCard
+// may be used.  If a string isn't a single valid card Cards panics with
+// the parse error, otherwise it returns a list of the corresponding
+// cards.
 func Cards(list ...string) []hand.Card {
 	cards := []hand.Card{}
 	for _, s := range list {
@@ -58,12 +55,25 @@ func Cards(list ...string) []hand.Card {
 	return cards
 }
 
+// CardSet returns the set of the cards in the list, which takes the same
+// format as the list given to Cards.
+func CardSet(list ...string) hand.CardSet {
+	return hand.NewCardSet(Cards(list...)...)
+}
+
 // Dealer returns a hand.Dealer that generates decks that will pop
 // cards in the order of the cards given.
 func Dealer(cards []hand.Card) hand.Dealer {
 	return &deck{cards: cards}
 }
 
+// SpecDealer returns a hand.Dealer that generates unshuffled decks of
+// the cards described by the spec that will pop cards in the order the
+// spec lists them.
+func SpecDealer(spec hand.DeckSpec) hand.Dealer {
+	return Dealer(spec.List())
+}
+
 type deck struct {
 	cards []hand.Card
 }
@@ -81,49 +91,9 @@ func (d deck) Deck() *hand.Deck {
 }
 
 func card(s string) hand.Card {
-	if len(s) != 2 {
-		panic("jokertest: card string must be two characters")
-	}
-
-	rank, ok := rankMap[s[:1]]
-	if !ok {
-		panic("jokertest: rank not found")
-	}
-
-	suit, ok := suitMap[s[1:]]
-	if !ok {
-		panic("jokertest: suit not found")
+	c, err := hand.ParseCard(s)
+	if err != nil {
+		panic("jokertest: " + err.Error())
 	}
-
-	for _, c := range hand.StandardCards() {
-		if rank == c.Rank() && suit == c.Suit() {
-			return c
-		}
-	}
-	panic("jokertest: card not found")
+	return c
 }
-
-var (
-	rankMap = map[string]hand.Rank{
-		"A": hand.Ace,
-		"K": hand.King,
-		"Q": hand.Queen,
-		"J": hand.Jack,
-		"T": hand.Ten,
-		"9": hand.Nine,
-		"8": hand.Eight,
-		"7": hand.Seven,
-		"6": hand.Six,
-		"5": hand.Five,
-		"4": hand.Four,
-		"3": hand.Three,
-		"2": hand.Two,
-	}
-
-	suitMap = map[string]hand.Suit{
-		"s": hand.Spades,
-		"h": hand.Hearts,
-		"d": hand.Diamonds,
-		"c": hand.Clubs,
-	}
-)
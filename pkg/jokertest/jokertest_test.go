@@ -18,3 +18,26 @@ func TestDeck(t *testing.T) {
 		}
 	}
 }
+
+func TestCardsPanics(t *testing.T) {
+	for _, s := range []string{"Zs", "QsQh", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected Cards(%q) to panic", s)
+				}
+			}()
+			jokertest.Cards(s)
+		}()
+	}
+	if cards := jokertest.Cards("10h", "a♠"); cards[0] != hand.TenHearts || cards[1] != hand.AceSpades {
+		t.Fatalf("expected T♥ A♠ got %v", cards)
+	}
+}
+
+func TestCardSet(t *testing.T) {
+	s := jokertest.CardSet("Qh", "Ks", "Qh")
+	if s.Count() != 2 || !s.Contains(hand.QueenHearts) || !s.Contains(hand.KingSpades) {
+		t.Fatalf("expected Q♥ K♠ got %v", s)
+	}
+}
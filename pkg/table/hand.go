@@ -134,6 +134,15 @@ func (h *Hand) ActivePlayer() *PlayerInHand {
 	return h.Seats[h.Active]
 }
 
+// Dealt returns the set of cards dealt to the players and the board.
+func (h *Hand) Dealt() hand.CardSet {
+	s := hand.NewCardSet(h.Board...)
+	for _, player := range h.Seats {
+		s.Add(player.Cards...)
+	}
+	return s
+}
+
 func (h *Hand) update() {
 	seat := h.nextToAct()
 	if seat != -1 {
@@ -161,7 +170,7 @@ func (h *Hand) setupRound() {
 		h.Deck = h.Table.dealer.Deck()
 		for _, seat := range h.orderedSeats() {
 			player := h.Seats[seat]
-			player.Cards = h.Deck.PopMulti(2)
+			player.Cards = h.Deck.PopMulti(h.Table.config.Variant.HoleCards())
 			h.contribute(player, h.Table.config.Stakes.Ante)
 		}
 		h.contribute(h.Seats[sb], h.Table.config.Stakes.SmallBlind)
@@ -169,9 +178,11 @@ func (h *Hand) setupRound() {
 		h.Active = h.Table.Next(bb)
 		// TODO bug w/ big blind having to go all and cost < bb
 	case Flop:
+		h.Deck.Burn()
 		h.Board = h.Deck.PopMulti(3)
 		h.Active = h.Table.Next(h.Table.button)
 	case Turn, River:
+		h.Deck.Burn()
 		h.Board = append(h.Board, h.Deck.Pop())
 		h.Active = h.Table.Next(h.Table.button)
 	}
@@ -184,10 +195,15 @@ const (
 	Split
 )
 
+// HandResult is a player's share of a pot.  Reason explains why the
+// player's hand won or split the pot against the best hand that didn't,
+// or why it tied if every eligible hand split the pot.  Reason is nil if
+// no other hand contested the pot.
 type HandResult struct {
 	Hand     *hand.Hand
 	PotShare PotShare
 	Chips    int
+	Reason   *hand.Explanation
 }
 
 func (h *Hand) calcResults() {
@@ -203,8 +219,7 @@ func (h *Hand) calcResults() {
 	}
 	hands := map[int]*hand.Hand{}
 	for seat, player := range h.Seats {
-		// TODO omaha
-		hands[seat] = hand.New(append(player.Cards, h.Board...))
+		hands[seat] = h.Table.config.Variant.Hand(player.Cards, h.Board, h.Table.config.handOptions()...)
 	}
 	results := map[int][]HandResult{}
 	for _, pot := range h.Pot.Split() {
@@ -218,13 +233,23 @@ func (h *Hand) calcResults() {
 		// select winners who split pot if more than one
 		winners := []int{}
 		h1 := hands[elegible[0]]
-		for seat := range elegible {
+		for _, seat := range elegible {
 			h2 := hands[seat]
 			if h1.CompareTo(h2) != 0 {
 				break
 			}
 			winners = append(winners, seat)
 		}
+		// explain against the best losing hand or another winner
+		var reason *hand.Explanation
+		if len(elegible) > 1 {
+			other := len(winners)
+			if other == len(elegible) {
+				other = 1
+			}
+			e := hand.Explain(h1, hands[elegible[other]])
+			reason = &e
+		}
 		// sort closest to the button for spare chips in split pot
 		sort.Slice(winners, func(i, j int) bool {
 			iDist := h.distanceFromButton(winners[i])
@@ -245,6 +270,7 @@ func (h *Hand) calcResults() {
 				Hand:     hands[seat],
 				PotShare: potshare,
 				Chips:    chips,
+				Reason:   reason,
 			}
 			results[seat] = append(results[seat], result)
 		}
@@ -4,8 +4,9 @@ import (
 	"encoding/json"
 	"testing"
 
-	"github.com/notnil/joker/jokertest"
-	"github.com/notnil/joker/table"
+	"github.com/notnil/joker/pkg/hand"
+	"github.com/notnil/joker/pkg/jokertest"
+	"github.com/notnil/joker/pkg/table"
 )
 
 func TestHand(t *testing.T) {
@@ -47,10 +48,153 @@ func TestHand(t *testing.T) {
 			t.Fatal(h.ActivePlayer(), h.LegalActions(), action, err, debugStr(h))
 		}
 	}
-	t.Fatal(h.Results)
+	if burned := h.Deck.Burned(); len(burned) != 3 || h.Dealt().Contains(burned[0]) {
+		t.Fatalf("expected a card burned before the flop, turn, and river got %v", burned)
+	}
+	results := h.Results[2]
+	if len(h.Results) != 1 || len(results) != 1 {
+		t.Fatalf("expected seat 2 to win a single pot got %v", h.Results)
+	}
+	if results[0].Chips != 10 || results[0].Hand.Ranking() != hand.StdTwoPair {
+		t.Fatalf("expected seat 2 to win 10 chips with two pair got %v", results[0])
+	}
+}
+
+func TestHandDealt(t *testing.T) {
+	config := table.Config{
+		Size:     10,
+		BuyInMin: 100,
+		BuyInMax: 300,
+		Stakes: table.Stakes{
+			SmallBlind: 1,
+			BigBlind:   2,
+		},
+	}
+	seats := map[int]*table.Player{
+		0: {ID: "0", Chips: 100},
+		1: {ID: "1", Chips: 100},
+		2: {ID: "2", Chips: 100},
+	}
+	tbl, err := table.New(config, seats, jokertest.Dealer(jokertest.Deck1().Cards))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := tbl.NewHand()
+	dealt := h.Dealt()
+	if dealt.Count() != 6 {
+		t.Fatalf("expected 6 dealt cards got %v", dealt)
+	}
+	for seat, player := range h.Seats {
+		if !dealt.ContainsAll(hand.NewCardSet(player.Cards...)) {
+			t.Fatalf("expected %v to contain the cards of seat %d %v", dealt, seat, player.Cards)
+		}
+	}
 }
 
 func debugStr(h *table.Hand) string {
 	b, _ := json.MarshalIndent(h, "", "\t")
 	return string(b)
 }
+
+func TestOmahaHand(t *testing.T) {
+	cards := jokertest.Cards(
+		"Ts", "3c", "4d", "8h", // seat 0
+		"9c", "9d", "2h", "2d", // seat 1
+		"5c", "As", "Ks", "Qs", // burn and flop
+		"5d", "Js", // burn and turn
+		"5h", "6c", // burn and river
+	)
+	config := table.Config{
+		Size:     2,
+		BuyInMin: 100,
+		BuyInMax: 300,
+		Variant:  table.OmahaHi,
+		Stakes: table.Stakes{
+			SmallBlind: 1,
+			BigBlind:   2,
+		},
+	}
+	seats := map[int]*table.Player{
+		0: {ID: "0", Chips: 100},
+		1: {ID: "1", Chips: 100},
+	}
+	tbl, err := table.New(config, seats, jokertest.Dealer(cards))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := tbl.NewHand()
+	if len(h.Seats[0].Cards) != 4 {
+		t.Fatalf("expected four hole cards got %v", h.Seats[0].Cards)
+	}
+	for i := 0; i < 8; i++ {
+		a := table.Action{Type: table.Check}
+		if i == 0 {
+			a = table.Action{Type: table.Call}
+		}
+		if err := h.Act(a); err != nil {
+			t.Fatal(h.ActivePlayer(), h.LegalActions(), a, err, debugStr(h))
+		}
+	}
+	results := h.Results[1]
+	if len(h.Results) != 1 || len(results) != 1 {
+		t.Fatalf("expected seat 1 to win a single pot got %v", h.Results)
+	}
+	if results[0].Chips != 4 || results[0].Hand.Description() != "pair of nines" {
+		t.Fatalf("expected seat 1 to win 4 chips with a pair of nines got %v", results[0])
+	}
+	if r := results[0].Reason; r == nil || r.Reason != hand.ReasonRanking || r.String() != "pair of nines beats high card ace high" {
+		t.Fatalf("expected a pair to beat high card got %v", r)
+	}
+	fromSets := table.OmahaHi.HandFromSets(hand.NewCardSet(h.Seats[1].Cards...), hand.NewCardSet(h.Board...))
+	if fromSets.CompareTo(results[0].Hand) != 0 {
+		t.Fatalf("expected %v from the card sets got %v", results[0].Hand, fromSets)
+	}
+}
+
+func TestMultiDeckHand(t *testing.T) {
+	cards := jokertest.Cards(
+		"As", "Ks", // seat 0
+		"As", "Qd", // seat 1
+		"5c", "Ad", "Kd", "Kc", // burn and flop
+		"5d", "2h", // burn and turn
+		"5h", "7c", // burn and river
+	)
+	config := table.Config{
+		Size:      2,
+		BuyInMin:  100,
+		BuyInMax:  300,
+		MultiDeck: true,
+		Stakes: table.Stakes{
+			SmallBlind: 1,
+			BigBlind:   2,
+		},
+	}
+	seats := map[int]*table.Player{
+		0: {ID: "0", Chips: 100},
+		1: {ID: "1", Chips: 100},
+	}
+	tbl, err := table.New(config, seats, jokertest.Dealer(cards))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := tbl.NewHand()
+	for i := 0; i < 8; i++ {
+		a := table.Action{Type: table.Check}
+		if i == 0 {
+			a = table.Action{Type: table.Call}
+		}
+		if err := h.Act(a); err != nil {
+			t.Fatal(h.ActivePlayer(), h.LegalActions(), a, err, debugStr(h))
+		}
+	}
+	results := h.Results[0]
+	if len(h.Results) != 1 || len(results) != 1 {
+		t.Fatalf("expected seat 0 to win a single pot got %v", h.Results)
+	}
+	// neither hand holds duplicate cards but both are ranked as hands
+	// dealt from a shoe
+	expected := hand.New(jokertest.Cards("As", "Ks", "Ad", "Kd", "Kc", "2h", "7c"), hand.MultiDeck)
+	if v := results[0].Hand.Value(); v != expected.Value() {
+		t.Fatalf("expected a multiple deck value of %d got %d", expected.Value(), v)
+	}
+}
@@ -3,7 +3,7 @@ package table_test
 import (
 	"testing"
 
-	"github.com/notnil/joker/table"
+	"github.com/notnil/joker/pkg/table"
 )
 
 func TestPot(t *testing.T) {
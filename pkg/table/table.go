@@ -20,6 +20,33 @@ const (
 	OmahaHi
 )
 
+// HoleCards returns the number of cards dealt to each player.
+func (v Variant) HoleCards() int {
+	switch v {
+	case OmahaHi:
+		return 4
+	default:
+		return 2
+	}
+}
+
+// Hand returns the best hand for the player's hole cards and the board
+// under the rules of the variant formed with the options.
+func (v Variant) Hand(holeCards, board []hand.Card, options ...func(*hand.Config)) *hand.Hand {
+	switch v {
+	case OmahaHi:
+		return hand.NewOmaha(holeCards, board, options...)
+	default:
+		return hand.New(append(append([]hand.Card{}, holeCards...), board...), options...)
+	}
+}
+
+// HandFromSets returns the best hand for the sets of hole cards and
+// board cards in the same way as Hand.
+func (v Variant) HandFromSets(holeCards, board hand.CardSet, options ...func(*hand.Config)) *hand.Hand {
+	return v.Hand(holeCards.Cards(), board.Cards(), options...)
+}
+
 type Limit int
 
 const (
@@ -33,13 +60,26 @@ type Stakes struct {
 	Ante       int `json:"ante"`
 }
 
+// Config is the configuration of a table.  MultiDeck should be set if
+// the table's dealer deals from more than one deck, so that every hand
+// is ranked with the same hand.MultiDeck rankings whether or not it
+// holds duplicate cards.
 type Config struct {
-	Size     int     `json:"size"`
-	BuyInMin int     `json:"buyInMin"`
-	BuyInMax int     `json:"buyInMax"`
-	Variant  Variant `json:"variant"`
-	Stakes   Stakes  `json:"stakes"`
-	Limit    Limit   `json:"limit"`
+	Size      int     `json:"size"`
+	BuyInMin  int     `json:"buyInMin"`
+	BuyInMax  int     `json:"buyInMax"`
+	Variant   Variant `json:"variant"`
+	Stakes    Stakes  `json:"stakes"`
+	Limit     Limit   `json:"limit"`
+	MultiDeck bool    `json:"multiDeck"`
+}
+
+// handOptions returns the options hands are formed with at the table.
+func (c Config) handOptions() []func(*hand.Config) {
+	if c.MultiDeck {
+		return []func(*hand.Config){hand.MultiDeck}
+	}
+	return nil
 }
 
 type Player struct {
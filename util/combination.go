@@ -0,0 +1,180 @@
+package util
+
+// Binomial returns the number of combinations of k out of n items.  It
+// returns zero if n or k are negative or k > n.
+func Binomial(n, k int) int {
+	if n < 0 || k < 0 || k > n {
+		return 0
+	}
+	if k > n-k {
+		k = n - k
+	}
+	b := 1
+	for i := 1; i <= k; i++ {
+		b = b * (n - k + i) / i
+	}
+	return b
+}
+
+// RankCombination returns the colexicographic rank of the combination,
+// which must be sorted in ascending order, as a dense integer from 0 to
+// Binomial(n, len(idx))-1 for any n greater than its last index.
+func RankCombination(idx []int) int {
+	rank := 0
+	for i, v := range idx {
+		rank += Binomial(v, i+1)
+	}
+	return rank
+}
+
+// UnrankCombination sets idx to the combination of len(idx) indexes with
+// the colexicographic rank, which is the inverse of RankCombination.
+func UnrankCombination(rank int, idx []int) {
+	for i := len(idx) - 1; i >= 0; i-- {
+		v := i
+		for Binomial(v+1, i+1) <= rank {
+			v++
+		}
+		idx[i] = v
+		rank -= Binomial(v, i+1)
+	}
+}
+
+// A CombinationIterator steps through the combinations of k out of n
+// indexes in colexicographic order without allocating.  The iterator
+// starts before its first combination, so Next must be called first:
+//
+//	it := util.NewCombinationIterator(52, 5)
+//	for it.Next() {
+//		idx := it.Combination()
+//	}
+type CombinationIterator struct {
+	idx   []int
+	rank  int
+	start int
+	end   int
+}
+
+// NewCombinationIterator returns an iterator over every combination of k
+// out of n indexes.  There is a single empty combination if k is zero
+// and none if n or k are negative or k > n.
+func NewCombinationIterator(n, k int) *CombinationIterator {
+	return newCombinationIterator(k, 0, Binomial(n, k))
+}
+
+// newCombinationIterator returns an iterator over the combinations of k
+// indexes with colexicographic ranks from start up to but not including
+// end.
+func newCombinationIterator(k, start, end int) *CombinationIterator {
+	if k < 0 {
+		k = 0
+	}
+	it := &CombinationIterator{idx: make([]int, k), rank: start - 1, start: start, end: end}
+	if start < end {
+		UnrankCombination(start, it.idx)
+	}
+	return it
+}
+
+// Next advances to the next combination and returns false once there are
+// none left.
+func (it *CombinationIterator) Next() bool {
+	if it.rank+1 >= it.end {
+		it.rank = it.end
+		return false
+	}
+	it.rank++
+	if it.rank > it.start {
+		it.advance()
+	}
+	return true
+}
+
+// advance moves idx to the next combination in colexicographic order:
+// the lowest index that can move up does and the ones below it reset.
+func (it *CombinationIterator) advance() {
+	k := len(it.idx)
+	for i := 0; i < k; i++ {
+		if i == k-1 || it.idx[i]+1 < it.idx[i+1] {
+			it.idx[i]++
+			for j := 0; j < i; j++ {
+				it.idx[j] = j
+			}
+			return
+		}
+	}
+}
+
+// Combination returns the indexes of the current combination in
+// ascending order.  The slice is reused and is only valid until the next
+// call to Next.
+func (it *CombinationIterator) Combination() []int {
+	return it.idx
+}
+
+// Rank returns the colexicographic rank of the current combination.
+func (it *CombinationIterator) Rank() int {
+	return it.rank
+}
+
+// ForEachCombination calls f with the indexes of every combination of k
+// out of n in colexicographic order until f returns false.  The slice
+// passed to f is reused between calls and ForEachCombination doesn't
+// allocate after setting it up.
+func ForEachCombination(n, k int, f func(idx []int) bool) {
+	it := NewCombinationIterator(n, k)
+	for it.Next() {
+		if !f(it.idx) {
+			return
+		}
+	}
+}
+
+// A CombinationChunk is the combinations of K out of N indexes with
+// colexicographic ranks from Start up to but not including End.
+type CombinationChunk struct {
+	N, K       int
+	Start, End int
+}
+
+// SplitCombinations splits the combinations of k out of n into at most
+// the given number of chunks of nearly equal size, in order, that can be
+// enumerated independently such as by separate goroutines.
+func SplitCombinations(n, k, chunks int) []CombinationChunk {
+	total := Binomial(n, k)
+	if chunks > total {
+		chunks = total
+	}
+	split := []CombinationChunk{}
+	for i := 0; i < chunks; i++ {
+		split = append(split, CombinationChunk{
+			N:     n,
+			K:     k,
+			Start: total * i / chunks,
+			End:   total * (i + 1) / chunks,
+		})
+	}
+	return split
+}
+
+// Len returns the number of combinations in the chunk.
+func (c CombinationChunk) Len() int {
+	return c.End - c.Start
+}
+
+// Iterator returns an iterator over the combinations of the chunk.
+func (c CombinationChunk) Iterator() *CombinationIterator {
+	return newCombinationIterator(c.K, c.Start, c.End)
+}
+
+// ForEach calls f with the indexes of every combination of the chunk in
+// colexicographic order until f returns false.  The slice passed to f is
+// reused between calls.
+func (c CombinationChunk) ForEach(f func(idx []int) bool) {
+	it := c.Iterator()
+	for it.Next() {
+		if !f(it.idx) {
+			return
+		}
+	}
+}
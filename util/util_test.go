@@ -49,3 +49,85 @@ func TestCombinations(t *testing.T) {
 		}
 	}
 }
+
+func TestCombinationIterator(t *testing.T) {
+	for _, c := range combos {
+		count := 0
+		seen := map[int]bool{}
+		it := util.NewCombinationIterator(c.n, c.k)
+		for it.Next() {
+			idx := it.Combination()
+			if len(idx) != c.k || util.RankCombination(idx) != it.Rank() || it.Rank() != count {
+				t.Fatalf("combination %v of %d, %d has rank %d, want %d", idx, c.n, c.k, it.Rank(), count)
+			}
+			key := 0
+			for i, v := range idx {
+				if v < 0 || v >= c.n || i > 0 && v <= idx[i-1] {
+					t.Fatalf("invalid combination %v of %d, %d", idx, c.n, c.k)
+				}
+				key |= 1 << uint(v)
+			}
+			seen[key] = true
+			count++
+		}
+		if count != len(c.combo) || len(seen) != count || util.Binomial(c.n, c.k) != count {
+			t.Fatalf("iterated %d combinations of %d, %d, want %d", count, c.n, c.k, len(c.combo))
+		}
+	}
+
+	// colexicographic order moves the highest index last
+	expected := [][]int{{0, 1}, {0, 2}, {1, 2}, {0, 3}, {1, 3}, {2, 3}}
+	result := [][]int{}
+	util.ForEachCombination(4, 2, func(idx []int) bool {
+		result = append(result, append([]int{}, idx...))
+		return true
+	})
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("util.ForEachCombination(4, 2) => %v, want %v", result, expected)
+	}
+	count := 0
+	util.ForEachCombination(3, 0, func(idx []int) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Fatalf("expected a single empty combination got %d", count)
+	}
+}
+
+func TestRankCombination(t *testing.T) {
+	if n := util.Binomial(52, 5); n != 2598960 {
+		t.Fatalf("util.Binomial(52, 5) => %d, want 2598960", n)
+	}
+	idx := make([]int, 5)
+	for _, rank := range []int{0, 1, 1000, 1234567, 2598959} {
+		util.UnrankCombination(rank, idx)
+		if r := util.RankCombination(idx); r != rank {
+			t.Fatalf("util.UnrankCombination(%d) => %v which ranks %d", rank, idx, r)
+		}
+	}
+	if !reflect.DeepEqual(idx, []int{47, 48, 49, 50, 51}) {
+		t.Fatalf("expected the last combination of 52, 5 got %v", idx)
+	}
+}
+
+func TestSplitCombinations(t *testing.T) {
+	for _, chunks := range []int{1, 3, 7, 100} {
+		next := 0
+		for _, chunk := range util.SplitCombinations(9, 4, chunks) {
+			it := chunk.Iterator()
+			for it.Next() {
+				if it.Rank() != next || util.RankCombination(it.Combination()) != next {
+					t.Fatalf("expected rank %d in %+v got %d", next, chunk, it.Rank())
+				}
+				next++
+			}
+		}
+		if next != util.Binomial(9, 4) {
+			t.Fatalf("expected %d chunks to cover %d combinations got %d", chunks, util.Binomial(9, 4), next)
+		}
+	}
+	if split := util.SplitCombinations(5, 3, 100); len(split) != 10 {
+		t.Fatalf("expected a chunk per combination got %d", len(split))
+	}
+}